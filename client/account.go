@@ -128,6 +128,7 @@ type Account struct {
 	RepoPermission              string              `json:"repoPermission,omitempty"`
 	Limits                      *Limits             `json:"limits,omitempty"`
 	Features                    map[string]bool     `json:"features,omitempty"`
+	Security                    *AccountSecurity    `json:"security,omitempty"`
 	// Features                    *Features           `json:"features,omitempty"`
 	// RuntimeEnvironments ToDo
 	// Remaining ToDo
@@ -218,15 +219,8 @@ func (client *Client) GetAllAccounts() (*[]Account, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var accounts []Account
-
-	err = DecodeResponseInto(resp, &accounts)
+	err := client.RequestAllPages(&opts, &accounts)
 	if err != nil {
 		return nil, err
 	}
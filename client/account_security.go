@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+)
+
+// AccountSecurity spec
+type AccountSecurity struct {
+	SessionTTL int  `json:"sessionTTL"`
+	EnforceMFA bool `json:"enforceMFA"`
+}
+
+// GetAllowedDomains returns the domains users can be invited from
+func (account *Account) GetAllowedDomains() []string {
+	domains := []string{}
+	for _, domain := range account.AllowedDomains {
+		if d, ok := domain.(string); ok {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// UpdateAccountSecurity overwrites the security settings and the allowed invite domains of the account.
+// UpdateAccount can't be used here because it merges into the existing account,
+// so zero values (e.g. disabling MFA or removing all domains) would be ignored
+func (client *Client) UpdateAccountSecurity(accountID string, security *AccountSecurity, allowedDomains []string) error {
+	if allowedDomains == nil {
+		allowedDomains = []string{}
+	}
+	return client.overwriteAccountDetails(accountID, map[string]interface{}{
+		"security":       security,
+		"allowedDomains": allowedDomains,
+	})
+}
+
+// UpdateAccountSSOEnforcement overwrites whether the users of the account can log in with a password
+// instead of the SSO, and the email domains users can be invited from
+func (client *Client) UpdateAccountSSOEnforcement(accountID string, passwordLogin bool, allowedDomains []string) error {
+	if allowedDomains == nil {
+		allowedDomains = []string{}
+	}
+	return client.overwriteAccountDetails(accountID, map[string]interface{}{
+		"localUserPasswordIDPEnabled": passwordLogin,
+		"allowedDomains":              allowedDomains,
+	})
+}
+
+// overwriteAccountDetails sets the fields of the account, including their zero values, and keeps the other ones
+func (client *Client) overwriteAccountDetails(accountID string, fields map[string]interface{}) error {
+
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return err
+	}
+
+	accountJSON, err := EncodeToJSON(account)
+	if err != nil {
+		return err
+	}
+
+	var accountDetails map[string]interface{}
+	err = DecodeResponseInto(accountJSON, &accountDetails)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		accountDetails[name] = value
+	}
+
+	body, err := EncodeToJSON(map[string]interface{}{"accountDetails": accountDetails})
+	if err != nil {
+		return err
+	}
+
+	opts := RequestOptions{
+		Path:   fmt.Sprintf("/admin/accounts/%s/update", accountID),
+		Method: "POST",
+		Body:   body,
+	}
+
+	_, err = client.RequestAPI(&opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
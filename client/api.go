@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// CodefreshAPI the Codefresh API used by the resources of the provider, implemented by Client.
+// Tools embedding the client, and tests, can provide their own implementation
+type CodefreshAPI interface {
+	// WithToken returns a client for the same API authenticated with another token
+	WithToken(token string) CodefreshAPI
+	// WithContext returns a client whose requests are canceled with ctx
+	WithContext(ctx context.Context) CodefreshAPI
+
+	// account
+	CreateAccount(account *Account) (*Account, error)
+	DeleteAccount(id string) error
+	GetAccountByID(id string) (*Account, error)
+	GetAccountByName(name string) (*Account, error)
+	GetAccountsList(accountsId []string) (*[]Account, error)
+	UpdateAccount(account *Account) (*Account, error)
+
+	// account security
+	UpdateAccountSecurity(accountID string, security *AccountSecurity, allowedDomains []string) error
+	UpdateAccountSSOEnforcement(accountID string, passwordLogin bool, allowedDomains []string) error
+
+	// api key
+	CreateApiKey(userID string, accountId string, apiKey *ApiKey) (string, error)
+	DeleteAPIKey(keyID string) error
+	GetAPIKey(keyID string) (*ApiKey, error)
+	GetApiKeysList() ([]ApiKey, error)
+	UpdateAPIKey(key *ApiKey) error
+
+	// ui
+	GetUIURL() string
+
+	// cluster
+	GetClusters() ([]Cluster, error)
+
+	// context
+	CreateContext(context *Context) (*Context, error)
+	DeleteContext(name string) error
+	GetContextWithDecrypt(name string, decrypt bool) (*Context, error)
+	GetContexts(filter *ContextsFilter) ([]Context, error)
+	UpdateContext(context *Context) (*Context, error)
+
+	// current account
+	GetCurrentAccount() (*CurrentAccount, error)
+	GetCurrentAccountParallelBuilds() (int, error)
+
+	// execution context
+	CreateExecutionContext(executionContext *ExecutionContext) (*ExecutionContext, error)
+	DeleteExecutionContext(id string) error
+	GetExecutionContext(id string) (*ExecutionContext, error)
+	UpdateExecutionContext(executionContext *ExecutionContext) error
+
+	// generic entity
+	RequestGenericEntity(method, path string, body []byte, idPath string) (*GenericEntity, error)
+
+	// gitops application
+	GetGitopsApplications(filter *GitopsApplicationsFilter) ([]GitopsApplication, error)
+	GetGitopsRuntimes() ([]GitopsRuntime, error)
+
+	// gitops cluster
+	AddGitopsCluster(cluster *GitopsCluster) (*GitopsCluster, error)
+	GetGitopsCluster(runtime, name string) (*GitopsCluster, error)
+	RemoveGitopsCluster(runtime, name string) error
+	UpdateGitopsCluster(cluster *GitopsCluster) error
+
+	// idp
+	AddAccountToIDP(accountId, idpId string) error
+	GetIDPs() (*[]IDP, error)
+	GetIdpByID(idpID string) (*IDP, error)
+
+	// permission
+	CreatePermission(permission *Permission) (*Permission, error)
+	DeletePermission(id string) error
+	GetPermissionByID(id string) (*Permission, error)
+	GetPermissionList(teamID, action, resource string) ([]Permission, error)
+
+	// pipeline
+	CreatePipeline(pipeline *Pipeline) (*Pipeline, error)
+	DeletePipeline(name string) error
+	GetPipeline(name string) (*Pipeline, error)
+	GetPipelineByName(name string) (*Pipeline, error)
+	GetPipelineUnknownSpecFields(name string) ([]string, error)
+	GetPipelineWithDecrypt(name string, decrypt bool) (*Pipeline, error)
+	GetPipelines(filter *PipelinesFilter) ([]Pipeline, error)
+	UpdatePipeline(pipeline *Pipeline) (*Pipeline, error)
+
+	// project
+	CreateProject(project *Project) (*Project, error)
+	DeleteProject(id string) error
+	GetProjectByID(id string) (*Project, error)
+	GetProjectByName(name string) (*Project, error)
+	UpdateProject(project *Project) error
+	UpdateProjectVariables(id string, variables []Variable) error
+
+	// step types
+	CreateStepTypes(stepTypes *StepTypes) (*StepTypes, error)
+	DeleteStepTypes(name string) error
+	GetStepTypes(identifier string) (*StepTypes, error)
+	GetStepTypesVersions(name string) ([]string, error)
+	UpdateStepTypes(stepTypes *StepTypes) (*StepTypes, error)
+
+	// team
+	AddUserToTeam(teamID, userID string) error
+	CreateTeam(team *Team) (*NewTeam, error)
+	DeleteTeam(id string) error
+	DeleteUserFromTeam(teamID, userID string) error
+	GetTeamByID(id string) (*Team, error)
+	GetTeamByName(name string) (*Team, error)
+	GetTeamList() ([]Team, error)
+	RenameTeam(teamID, name string) error
+
+	// user
+	ActivateUser(userId string) (*User, error)
+	AddPendingUser(user *NewUser) (*User, error)
+	AddUserToTeamByAdmin(userID string, accountID string, team string) error
+	DeleteUser(userName string) error
+	DeleteUserAsAccountAdmin(accountId, userId string) error
+	GetAllUsers() (*[]User, error)
+	GetUserByID(userId string) (*User, error)
+	SendUserInvitation(accountID string, invitation *UserInvitation) error
+	SetUserAsAccountAdmin(accountId, userId string) error
+	UpdateUserAccounts(userId string, accounts []Account) error
+
+	// wait
+	WaitForAccountReady(id string, timeout time.Duration) error
+	WaitForWorkflowTemplateReady(runtime, name string, timeout time.Duration) error
+
+	// workflow template
+	CreateWorkflowTemplate(template *WorkflowTemplate) (*WorkflowTemplate, error)
+	DeleteWorkflowTemplate(runtime, name string) error
+	GetWorkflowTemplate(runtime, name string) (*WorkflowTemplate, error)
+	UpdateWorkflowTemplate(template *WorkflowTemplate) error
+}
+
+var _ CodefreshAPI = (*Client)(nil)
@@ -28,6 +28,7 @@ type ApiKey struct {
 	TokenPrefix   string              `json:"tokenPrefix,omitempty"`
 	ScopeSnapshot ApiKeyScopeSnapshot `json:"scopeSnapshot,omitempty"`
 	Created       string              `json:"created,omitempty"`
+	LastUsed      string              `json:"lastUsed,omitempty"`
 }
 
 func (client *Client) GetAPIKey(keyID string) (*ApiKey, error) {
@@ -135,10 +136,11 @@ func (client *Client) CreateApiKey(userID string, accountId string, apiKey *ApiK
 func (client *Client) GetXAccessToken(userID string, accountId string) (string, error) {
 
 	url := fmt.Sprintf("%s/admin/user/loginAsUser?userId=%s", client.Host, userID)
-	request, err := http.NewRequest("GET", url, nil)
+	request, err := http.NewRequestWithContext(client.requestContext(), "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
+	client.setAdditionalHeaders(request)
 
 	request.Header.Set("Authorization", client.Token)
 	request.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -163,10 +165,11 @@ func (client *Client) GetXAccessToken(userID string, accountId string) (string,
 
 	// change account
 	changeAccURL := fmt.Sprintf("%s/user/changeaccount/%s", client.Host, accountId)
-	changeAccRequest, err := http.NewRequest("POST", changeAccURL, nil)
+	changeAccRequest, err := http.NewRequestWithContext(client.requestContext(), "POST", changeAccURL, nil)
 	if err != nil {
 		return "", err
 	}
+	client.setAdditionalHeaders(changeAccRequest)
 
 	changeAccRequest.Header.Set("x-access-token", userCfAccessToken)
 	changeAccRequest.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -220,15 +223,8 @@ func (client *Client) GetApiKeysList() ([]ApiKey, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var apiKeys []ApiKey
-
-	err = DecodeResponseInto(resp, &apiKeys)
+	err := client.RequestAllPages(&opts, &apiKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -2,19 +2,39 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
+// idempotencyKeyHeader the header identifying the attempts of the same create request
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// gzipRequestThreshold the minimal size of the request bodies compressed when GzipRequests is enabled
+const gzipRequestThreshold = 64 * 1024
+
 // Client token, host, htpp.Client
 type Client struct {
-	Token       string
-	TokenHeader string
-	Host        string
-	Client      *http.Client
+	Token        string
+	TokenHeader  string
+	Host         string
+	HostV2       string
+	GzipRequests bool
+	ReadOnly     bool
+	// AdditionalHeaders are sent with every request, e.g. for the auth proxies of on-premises installations
+	AdditionalHeaders map[string]string
+	Client            *http.Client
+	// ctx cancels the requests of the client, e.g. when Terraform is interrupted
+	ctx context.Context
 }
 
 // RequestOptions  path, method, etc
@@ -27,14 +47,15 @@ type RequestOptions struct {
 }
 
 // NewClient returns a new client configured to communicate on a server with the
-// given hostname and to send an Authorization Header with the value of
+// given hostname (and hostnameV2 for the GitOps API) and to send an Authorization Header with the value of
 // token
-func NewClient(hostname string, token string, tokenHeader string) *Client {
+func NewClient(hostname string, hostnameV2 string, token string, tokenHeader string) *Client {
 	if tokenHeader == "" {
 		tokenHeader = "Authorization"
 	}
 	return &Client{
 		Host:        hostname,
+		HostV2:      hostnameV2,
 		Token:       token,
 		TokenHeader: tokenHeader,
 		Client:      &http.Client{},
@@ -42,16 +63,89 @@ func NewClient(hostname string, token string, tokenHeader string) *Client {
 
 }
 
+// WithToken returns a copy of the client authenticated with token
+func (client *Client) WithToken(token string) CodefreshAPI {
+	clientWithToken := *client
+	clientWithToken.Token = token
+	return &clientWithToken
+}
+
+// WithContext returns a copy of the client whose requests are canceled with ctx
+func (client *Client) WithContext(ctx context.Context) CodefreshAPI {
+	clientWithContext := *client
+	clientWithContext.ctx = ctx
+	return &clientWithContext
+}
+
+// requestContext returns the context of the requests of the client
+func (client *Client) requestContext() context.Context {
+	if client.ctx == nil {
+		return context.Background()
+	}
+	return client.ctx
+}
+
+// GetUIURL returns the URL of the Codefresh UI, which is served by the same host as the API
+func (client *Client) GetUIURL() string {
+	return strings.TrimSuffix(strings.TrimSuffix(client.Host, "/"), "/api")
+}
+
 // RequestAPI http request to Codefresh API
 func (client *Client) RequestAPI(opt *RequestOptions) ([]byte, error) {
+	resp, err := client.doRequestAPI(opt)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read body %v %v", resp.StatusCode, resp.Status)
+	}
+	return body, nil
+}
+
+// RequestAPIInto http request to Codefresh API, the response is decoded into target while it's read
+// instead of being buffered, to reduce the memory used by large payloads such as pipelines with inline YAML
+func (client *Client) RequestAPIInto(opt *RequestOptions, target interface{}) error {
+	resp, err := client.doRequestAPI(opt)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// doRequestAPI sends the request and returns the response when its status is 200, the caller closes the body.
+// The response is compressed with gzip when the server supports it, as the http.Transport
+// requests it and decompresses it transparently
+func (client *Client) doRequestAPI(opt *RequestOptions) (*http.Response, error) {
+	if err := client.checkReadOnly(opt.Method, opt.Path); err != nil {
+		return nil, err
+	}
+
 	finalURL := fmt.Sprintf("%s%s", client.Host, opt.Path)
 	if opt.QS != nil {
 		finalURL += ToQS(opt.QS)
 	}
-	request, err := http.NewRequest(opt.Method, finalURL, bytes.NewBuffer(opt.Body))
+
+	body := opt.Body
+	compressed := false
+	if client.GzipRequests && len(body) > gzipRequestThreshold {
+		gzipped, err := gzipBody(body)
+		if err != nil {
+			return nil, err
+		}
+		body = gzipped
+		compressed = true
+	}
+
+	request, err := http.NewRequestWithContext(client.requestContext(), opt.Method, finalURL, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
+	client.setAdditionalHeaders(request)
 
 	tokenHeader := client.TokenHeader
 	if tokenHeader == "" {
@@ -59,34 +153,103 @@ func (client *Client) RequestAPI(opt *RequestOptions) ([]byte, error) {
 	}
 	request.Header.Set(tokenHeader, client.Token)
 	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if compressed {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	if opt.Method == "POST" {
+		// the same key is sent by the retries of the request, so that the API can ignore the duplicates
+		request.Header.Set(idempotencyKeyHeader, newIdempotencyKey())
+	}
 
 	resp, err := client.Client.Do(request)
 
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to read body %v %v", resp.StatusCode, resp.Status)
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read body %v %v", resp.StatusCode, resp.Status)
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
 	}
+	return resp, nil
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%v, %s", resp.Status, string(body))
+// APIError error returned by the API with a status different from 200
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf("%v, %s", err.Status, err.Body)
+}
+
+// IsConflictError returns whether the request failed because the entity already exists
+func IsConflictError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsUnknownOutcomeError returns whether the request may have been processed by the API although it failed,
+// i.e. the connection was lost or a gateway timed out waiting for the API
+func IsUnknownOutcomeError(err error) bool {
+	if err == nil {
+		return false
 	}
-	return body, nil
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		var urlErr *url.Error
+		return errors.As(err, &urlErr)
+	}
+	return apiErr.StatusCode == http.StatusBadGateway || apiErr.StatusCode == http.StatusGatewayTimeout
+}
+
+// IsNotFoundError returns whether the request failed because the entity doesn't exist (yet)
+func IsNotFoundError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// newIdempotencyKey returns a random key identifying a request
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(key)
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func (client *Client) RequestApiXAccessToken(opt *RequestOptions) ([]byte, error) {
+	if err := client.checkReadOnly(opt.Method, opt.Path); err != nil {
+		return nil, err
+	}
+
 	finalURL := fmt.Sprintf("%s%s", client.Host, opt.Path)
 	if opt.QS != nil {
 		finalURL += ToQS(opt.QS)
 	}
-	request, err := http.NewRequest(opt.Method, finalURL, bytes.NewBuffer(opt.Body))
+	request, err := http.NewRequestWithContext(client.requestContext(), opt.Method, finalURL, bytes.NewBuffer(opt.Body))
 	if err != nil {
 		return nil, err
 	}
+	client.setAdditionalHeaders(request)
 
 	request.Header.Set("x-access-token", opt.XAccessToken)
 	request.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -109,11 +272,27 @@ func (client *Client) RequestApiXAccessToken(opt *RequestOptions) ([]byte, error
 	return body, nil
 }
 
+// setAdditionalHeaders sets the additional headers of the client, before the headers set by the client itself
+// so that they can't override the authentication
+func (client *Client) setAdditionalHeaders(request *http.Request) {
+	for name, value := range client.AdditionalHeaders {
+		request.Header.Set(name, value)
+	}
+}
+
+// checkReadOnly refuses the requests which change data when the client is read only
+func (client *Client) checkReadOnly(method string, path string) error {
+	if client.ReadOnly && method != "GET" {
+		return fmt.Errorf("[ERROR] The provider is configured with read_only = true, refusing to send %s %s", method, path)
+	}
+	return nil
+}
+
 // ToQS add extra parameters to path
 func ToQS(qs map[string]string) string {
 	var arr = []string{}
 	for k, v := range qs {
-		arr = append(arr, fmt.Sprintf("%s=%s", k, v))
+		arr = append(arr, fmt.Sprintf("%s=%s", k, url.QueryEscape(v)))
 	}
 	return "?" + strings.Join(arr, "&")
 }
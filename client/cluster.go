@@ -0,0 +1,30 @@
+package client
+
+// Cluster a Kubernetes cluster integration of the account
+type Cluster struct {
+	ID       string   `json:"_id,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+	Provider string   `json:"provider,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// GetID implement CodefreshObject interface
+func (cluster *Cluster) GetID() string {
+	return cluster.ID
+}
+
+// GetClusters returns the Kubernetes cluster integrations of the account
+func (client *Client) GetClusters() ([]Cluster, error) {
+	opts := RequestOptions{
+		Path:   "/clusters",
+		Method: "GET",
+	}
+
+	var clusters []Cluster
+	err := client.RequestAllPages(&opts, &clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
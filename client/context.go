@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"strings"
 )
 
 type ContextErrorResponse struct {
@@ -23,16 +24,32 @@ type Context struct {
 }
 
 type ContextSpec struct {
-	Type string                 `json:"type,omitempty"`
-	Data map[string]interface{} `json:"data,omitempty"`
+	Type          string                 `json:"type,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	EncryptedKeys []string               `json:"encryptedKeys,omitempty"`
 }
 
 func (context *Context) GetID() string {
 	return context.Metadata.Name
 }
 
+// ContextsFilter server side filters for the contexts list, empty fields are ignored
+type ContextsFilter struct {
+	Types   []string
+	Decrypt bool
+}
+
 func (client *Client) GetContext(name string) (*Context, error) {
-	fullPath := fmt.Sprintf("/contexts/%s?decrypt=true", url.PathEscape(name))
+	return client.GetContextWithDecrypt(name, true)
+}
+
+// GetContextWithDecrypt get the context, the secret values are only decrypted when decrypt is true.
+// Accounts with forbidDecrypt enabled reject the requests to decrypt
+func (client *Client) GetContextWithDecrypt(name string, decrypt bool) (*Context, error) {
+	fullPath := fmt.Sprintf("/contexts/%s", url.PathEscape(name))
+	if decrypt {
+		fullPath += "?decrypt=true"
+	}
 	opts := RequestOptions{
 		Path:   fullPath,
 		Method: "GET",
@@ -53,6 +70,36 @@ func (client *Client) GetContext(name string) (*Context, error) {
 
 }
 
+// GetContexts returns the contexts matching the filter with a single request, the secret values are only
+// decrypted when the filter asks for it
+func (client *Client) GetContexts(filter *ContextsFilter) ([]Context, error) {
+	qs := map[string]string{}
+	if filter != nil {
+		if len(filter.Types) > 0 {
+			qs["type"] = strings.Join(filter.Types, ",")
+		}
+		if filter.Decrypt {
+			qs["decrypt"] = "true"
+		}
+	}
+
+	opts := RequestOptions{
+		Path:   "/contexts",
+		Method: "GET",
+	}
+	if len(qs) > 0 {
+		opts.QS = qs
+	}
+
+	var contexts []Context
+	err := client.RequestAllPages(&opts, &contexts)
+	if err != nil {
+		return nil, err
+	}
+
+	return contexts, nil
+}
+
 func (client *Client) CreateContext(context *Context) (*Context, error) {
 
 	body, err := EncodeToJSON(context)
@@ -1,7 +1,6 @@
 package client
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/stretchr/objx"
 )
@@ -59,18 +58,14 @@ func (client *Client) GetCurrentAccount() (*CurrentAccount, error) {
 	}
 
 	// get and parse account users
-	accountUsersResp, err := client.RequestAPI(&RequestOptions{
+	accountUsersI := make([]interface{}, 0)
+	err = client.RequestAllPages(&RequestOptions{
 		Path:   fmt.Sprintf("/accounts/%s/users", currentAccount.ID),
 		Method: "GET",
-	})
+	}, &accountUsersI)
 	if err != nil {
 		return nil, err
 	}
-
-	accountUsersI := make([]interface{}, 0)
-	if e := json.Unmarshal(accountUsersResp, &accountUsersI); e != nil {
-		return nil, fmt.Errorf("Cannot unmarshal accountUsers responce for accountId=%s: %v", currentAccount.ID, e)
-	}
 	for _, userI := range accountUsersI {
 		userX := objx.New(userI)
 		userName := userX.Get("userName").String()
@@ -85,3 +80,28 @@ func (client *Client) GetCurrentAccount() (*CurrentAccount, error) {
 
 	return currentAccount, nil
 }
+
+// GetCurrentAccountParallelBuilds returns the number of builds the account of the token can run in parallel,
+// zero when the account doesn't report it
+func (client *Client) GetCurrentAccountParallelBuilds() (int, error) {
+	userResp, err := client.RequestAPI(&RequestOptions{
+		Path:   "/user",
+		Method: "GET",
+	})
+	if err != nil {
+		return 0, err
+	}
+	currentAccountX, err := objx.FromJSON(string(userResp))
+	if err != nil {
+		return 0, err
+	}
+
+	activeAccountName := currentAccountX.Get("activeAccountName").String()
+	for _, accI := range currentAccountX.Get("account").InterSlice() {
+		accX := objx.New(accI)
+		if accX.Get("name").String() == activeAccountName {
+			return int(accX.Get("build.parallel").Float64()), nil
+		}
+	}
+	return 0, fmt.Errorf("GetCurrentAccountParallelBuilds - cannot get activeAccountName")
+}
@@ -0,0 +1,23 @@
+// Package client is the Go client of the Codefresh API used by the Terraform provider. It can be used on its own
+// by the tools automating Codefresh without Terraform:
+//
+//	cf, err := client.New("https://g.codefresh.io/api",
+//		client.WithAPIToken(os.Getenv("CODEFRESH_API_KEY")),
+//		client.WithRateLimit(10),
+//		client.WithRetryPolicy(3, 500*time.Millisecond),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	pipeline, err := cf.WithContext(ctx).GetPipelineByName("project/build")
+//	if client.IsNotFoundError(err) {
+//		...
+//	}
+//
+// The API is described by the CodefreshAPI interface, implemented by Client, so that the tools can mock it in their
+// tests. The errors returned with a status from the API are of type *APIError, use IsNotFoundError, IsConflictError
+// and IsUnknownOutcomeError to check them, also when they are wrapped.
+//
+// The exported API of the package follows the semantic versioning of the provider releases: the breaking changes,
+// e.g. of the signatures of CodefreshAPI, are only made in major versions.
+package client
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ExecutionContextSpec the cloud identity assumed by the builds with OIDC
+type ExecutionContextSpec struct {
+	CloudProvider   string `json:"cloudProvider"`
+	Role            string `json:"role"`
+	Audience        string `json:"audience,omitempty"`
+	Region          string `json:"region,omitempty"`
+	SessionDuration int    `json:"sessionDuration,omitempty"`
+}
+
+// ExecutionContext spec
+type ExecutionContext struct {
+	ID   string               `json:"_id,omitempty"`
+	Name string               `json:"name"`
+	Spec ExecutionContextSpec `json:"spec"`
+}
+
+// GetID implement CodefreshObject interface
+func (executionContext *ExecutionContext) GetID() string {
+	return executionContext.ID
+}
+
+// GetExecutionContext get the execution context by id
+func (client *Client) GetExecutionContext(id string) (*ExecutionContext, error) {
+	fullPath := fmt.Sprintf("/execution-contexts/%s", id)
+	opts := RequestOptions{
+		Path:   fullPath,
+		Method: "GET",
+	}
+
+	resp, err := client.RequestAPI(&opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var executionContext ExecutionContext
+
+	err = DecodeResponseInto(resp, &executionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &executionContext, nil
+}
+
+// CreateExecutionContext POST execution context
+func (client *Client) CreateExecutionContext(executionContext *ExecutionContext) (*ExecutionContext, error) {
+
+	body, err := EncodeToJSON(executionContext)
+
+	if err != nil {
+		return nil, err
+	}
+	opts := RequestOptions{
+		Path:   "/execution-contexts",
+		Method: "POST",
+		Body:   body,
+	}
+
+	resp, err := client.RequestAPI(&opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var respExecutionContext ExecutionContext
+	err = DecodeResponseInto(resp, &respExecutionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &respExecutionContext, nil
+}
+
+// UpdateExecutionContext PUT execution context
+func (client *Client) UpdateExecutionContext(executionContext *ExecutionContext) error {
+
+	body, err := EncodeToJSON(executionContext)
+
+	if err != nil {
+		return err
+	}
+
+	id := executionContext.GetID()
+	if id == "" {
+		return errors.New("[ERROR] Execution context ID is empty")
+	}
+
+	fullPath := fmt.Sprintf("/execution-contexts/%s", id)
+	opts := RequestOptions{
+		Path:   fullPath,
+		Method: "PUT",
+		Body:   body,
+	}
+
+	_, err = client.RequestAPI(&opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExecutionContext DELETE
+func (client *Client) DeleteExecutionContext(id string) error {
+	fullPath := fmt.Sprintf("/execution-contexts/%s", id)
+	opts := RequestOptions{
+		Path:   fullPath,
+		Method: "DELETE",
+	}
+
+	_, err := client.RequestAPI(&opts)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
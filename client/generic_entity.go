@@ -0,0 +1,50 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/stretchr/objx"
+)
+
+// GenericEntityIDPlaceholder is replaced by the entity id in the paths of a generic entity
+const GenericEntityIDPlaceholder = "{id}"
+
+// GenericEntity spec, any Codefresh API entity not modeled by a dedicated resource
+type GenericEntity struct {
+	ID       string
+	Response string
+}
+
+// RequestGenericEntity sends the body as is to the given path and returns the raw response.
+// If idPath is set, the id of the entity is extracted from the response using it
+func (client *Client) RequestGenericEntity(method, path string, body []byte, idPath string) (*GenericEntity, error) {
+
+	opts := RequestOptions{
+		Path:   path,
+		Method: method,
+		Body:   body,
+	}
+
+	resp, err := client.RequestAPI(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := &GenericEntity{
+		Response: string(resp),
+	}
+
+	if idPath != "" {
+		respX, err := objx.FromJSON(entity.Response)
+		if err != nil {
+			return nil, fmt.Errorf("RequestGenericEntity - cannot parse the response of %s %s: %v", method, path, err)
+		}
+		idValue := respX.Get(idPath)
+		if idValue.IsNil() || idValue.String() == "" {
+			return nil, fmt.Errorf("RequestGenericEntity - cannot find %q in the response of %s %s", idPath, method, path)
+		}
+		entity.ID = idValue.String()
+	}
+
+	return entity, nil
+}
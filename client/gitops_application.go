@@ -0,0 +1,76 @@
+package client
+
+// GitopsApplicationsFilter filter for the GitOps applications query, empty fields are ignored
+type GitopsApplicationsFilter struct {
+	Runtime string `json:"runtime,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+	Health  string `json:"health,omitempty"`
+}
+
+// GitopsApplicationMetadata identifies the application in a GitOps runtime
+type GitopsApplicationMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Runtime   string `json:"runtime"`
+	Cluster   string `json:"cluster"`
+}
+
+// GitopsApplicationStatus sync and health status of the application
+type GitopsApplicationStatus struct {
+	HealthStatus string `json:"healthStatus"`
+	SyncStatus   string `json:"syncStatus"`
+}
+
+// GitopsApplication spec
+type GitopsApplication struct {
+	Metadata GitopsApplicationMetadata `json:"metadata"`
+	Status   GitopsApplicationStatus   `json:"healthStatus"`
+}
+
+type gitopsApplicationsResponse struct {
+	Applications struct {
+		Edges []struct {
+			Node GitopsApplication `json:"node"`
+		} `json:"edges"`
+	} `json:"applications"`
+}
+
+// GetGitopsApplications returns the GitOps applications matching the filter
+func (client *Client) GetGitopsApplications(filter *GitopsApplicationsFilter) ([]GitopsApplication, error) {
+	request := GraphQLRequest{
+		Query: `query Applications($filters: ApplicationFilterArgs) {
+			applications(filters: $filters) {
+				edges {
+					node {
+						metadata {
+							name
+							namespace
+							runtime
+							cluster
+						}
+						healthStatus {
+							healthStatus
+							syncStatus
+						}
+					}
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"filters": filter,
+		},
+	}
+
+	var resp gitopsApplicationsResponse
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	applications := make([]GitopsApplication, len(resp.Applications.Edges))
+	for i, edge := range resp.Applications.Edges {
+		applications[i] = edge.Node
+	}
+
+	return applications, nil
+}
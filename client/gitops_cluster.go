@@ -0,0 +1,120 @@
+package client
+
+import (
+	"fmt"
+)
+
+// GitopsClusterMetadata identifies the cluster managed by a GitOps runtime
+type GitopsClusterMetadata struct {
+	Name    string            `json:"name"`
+	Runtime string            `json:"runtime"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// GitopsClusterConfig credentials of the cluster, they are write only and never returned by the API
+type GitopsClusterConfig struct {
+	BearerToken string `json:"bearerToken,omitempty"`
+	Kubeconfig  string `json:"kubeconfig,omitempty"`
+}
+
+// GitopsCluster spec
+type GitopsCluster struct {
+	Metadata         GitopsClusterMetadata `json:"metadata"`
+	Server           string                `json:"server"`
+	Config           *GitopsClusterConfig  `json:"config,omitempty"`
+	ConnectionStatus string                `json:"connectionStatus,omitempty"`
+}
+
+// GetID implement CodefreshObject interface
+func (cluster *GitopsCluster) GetID() string {
+	return fmt.Sprintf("%s/%s", cluster.Metadata.Runtime, cluster.Metadata.Name)
+}
+
+const gitopsClusterFields = `
+	metadata {
+		name
+		runtime
+		labels
+	}
+	server
+	connectionStatus`
+
+// GetGitopsCluster get the cluster by its runtime and name, returns nil when it doesn't exist
+func (client *Client) GetGitopsCluster(runtime, name string) (*GitopsCluster, error) {
+	request := GraphQLRequest{
+		Query: `query Cluster($runtime: String!, $name: String!) {
+			cluster(runtime: $runtime, name: $name) {` + gitopsClusterFields + `
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"runtime": runtime,
+			"name":    name,
+		},
+	}
+
+	var resp struct {
+		Cluster *GitopsCluster `json:"cluster"`
+	}
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Cluster, nil
+}
+
+// AddGitopsCluster registers the external cluster with the runtime
+func (client *Client) AddGitopsCluster(cluster *GitopsCluster) (*GitopsCluster, error) {
+	request := GraphQLRequest{
+		Query: `mutation AddCluster($args: AddClusterArgs!) {
+			addCluster(args: $args) {` + gitopsClusterFields + `
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"args": cluster,
+		},
+	}
+
+	var resp struct {
+		AddCluster *GitopsCluster `json:"addCluster"`
+	}
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.AddCluster, nil
+}
+
+// UpdateGitopsCluster updates the labels of the cluster, and its credentials when they are set
+func (client *Client) UpdateGitopsCluster(cluster *GitopsCluster) error {
+	request := GraphQLRequest{
+		Query: `mutation UpdateCluster($args: AddClusterArgs!) {
+			updateCluster(args: $args) {
+				metadata {
+					name
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"args": cluster,
+		},
+	}
+
+	return client.SendGqlRequest(&request, nil)
+}
+
+// RemoveGitopsCluster removes the cluster from the runtime, the applications deployed to it are not deleted
+func (client *Client) RemoveGitopsCluster(runtime, name string) error {
+	request := GraphQLRequest{
+		Query: `mutation RemoveCluster($runtime: String!, $name: String!) {
+			removeCluster(runtime: $runtime, name: $name)
+		}`,
+		Variables: map[string]interface{}{
+			"runtime": runtime,
+			"name":    name,
+		},
+	}
+
+	return client.SendGqlRequest(&request, nil)
+}
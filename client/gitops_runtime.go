@@ -0,0 +1,68 @@
+package client
+
+// GitopsRuntimeMetadata identifies the GitOps runtime
+type GitopsRuntimeMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// GitopsRuntimeStatus sync and health status of the runtime's own application
+type GitopsRuntimeStatus struct {
+	HealthStatus string `json:"healthStatus"`
+	SyncStatus   string `json:"syncStatus"`
+}
+
+// GitopsRuntime spec
+type GitopsRuntime struct {
+	Metadata       GitopsRuntimeMetadata `json:"metadata"`
+	Status         GitopsRuntimeStatus   `json:"self"`
+	RuntimeVersion string                `json:"runtimeVersion"`
+	Cluster        string                `json:"cluster"`
+	IngressHost    string                `json:"ingressHost"`
+}
+
+type gitopsRuntimesResponse struct {
+	Runtimes struct {
+		Edges []struct {
+			Node GitopsRuntime `json:"node"`
+		} `json:"edges"`
+	} `json:"runtimes"`
+}
+
+// GetGitopsRuntimes returns the GitOps runtimes of the account
+func (client *Client) GetGitopsRuntimes() ([]GitopsRuntime, error) {
+	request := GraphQLRequest{
+		Query: `query Runtimes {
+			runtimes {
+				edges {
+					node {
+						metadata {
+							name
+							namespace
+						}
+						self {
+							healthStatus
+							syncStatus
+						}
+						runtimeVersion
+						cluster
+						ingressHost
+					}
+				}
+			}
+		}`,
+	}
+
+	var resp gitopsRuntimesResponse
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimes := make([]GitopsRuntime, len(resp.Runtimes.Edges))
+	for i, edge := range resp.Runtimes.Edges {
+		runtimes[i] = edge.Node
+	}
+
+	return runtimes, nil
+}
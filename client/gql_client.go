@@ -0,0 +1,93 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// GraphQLRequest GraphQL query
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLError error returned by the GraphQL API
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// SendGqlRequest http request to the Codefresh GitOps GraphQL API, decodes the data of the response into target
+func (client *Client) SendGqlRequest(request *GraphQLRequest, target interface{}) error {
+	if client.HostV2 == "" {
+		return fmt.Errorf("[ERROR] api_url_v2 is required for the GitOps resources")
+	}
+
+	// the GraphQL queries are sent with POST too, only the mutations change data
+	if strings.HasPrefix(strings.TrimSpace(request.Query), "mutation") {
+		if err := client.checkReadOnly("POST", "/api/graphql"); err != nil {
+			return err
+		}
+	}
+
+	body, err := EncodeToJSON(request)
+	if err != nil {
+		return err
+	}
+
+	finalURL := fmt.Sprintf("%s/api/graphql", client.HostV2)
+	httpRequest, err := http.NewRequestWithContext(client.requestContext(), "POST", finalURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	client.setAdditionalHeaders(httpRequest)
+
+	tokenHeader := client.TokenHeader
+	if tokenHeader == "" {
+		tokenHeader = "Authorization"
+	}
+	httpRequest.Header.Set(tokenHeader, client.Token)
+	httpRequest.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := client.Client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Failed to read body %v %v", resp.StatusCode, resp.Status)
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("%v, %s", resp.Status, string(respBody))
+	}
+
+	var gqlResponse graphQLResponse
+	err = DecodeResponseInto(respBody, &gqlResponse)
+	if err != nil {
+		return err
+	}
+
+	if len(gqlResponse.Errors) > 0 {
+		var messages []string
+		for _, gqlError := range gqlResponse.Errors {
+			messages = append(messages, gqlError.Message)
+		}
+		return fmt.Errorf("[ERROR] GraphQL request failed: %s", strings.Join(messages, "; "))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return DecodeResponseInto(gqlResponse.Data, target)
+}
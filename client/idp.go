@@ -42,15 +42,8 @@ func (client *Client) GetIDPs() (*[]IDP, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var idps []IDP
-
-	err = DecodeResponseInto(resp, &idps)
+	err := client.RequestAllPages(&opts, &idps)
 	if err != nil {
 		return nil, err
 	}
@@ -99,15 +92,8 @@ func (client *Client) GetAccountIDPs() (*[]IDP, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var idps []IDP
-
-	err = DecodeResponseInto(resp, &idps)
+	err := client.RequestAllPages(&opts, &idps)
 	if err != nil {
 		return nil, err
 	}
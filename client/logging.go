@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces the secrets in the logged requests and responses
+const redactedValue = "REDACTED"
+
+// maxLoggedBodySize truncates the logged bodies, e.g. of the pipelines with a large inline YAML
+const maxLoggedBodySize = 16 * 1024
+
+// sensitiveFieldNames the parts of the names of the JSON fields, headers and query parameters whose value is redacted
+var sensitiveFieldNames = []string{"token", "password", "secret", "credential", "apikey", "api_key", "privatekey", "private_key", "cookie", "authorization"}
+
+// plainContextTypes the types of the contexts whose data is logged, the data of the other types is redacted as it holds
+// secrets, e.g. the kubeconfig of the kubernetes contexts or the keys of the storage contexts
+var plainContextTypes = map[string]bool{"config": true, "yaml": true}
+
+// RequestLoggingEnabled whether the requests are logged, i.e. TF_LOG or TF_LOG_PROVIDER is DEBUG, TRACE or JSON
+func RequestLoggingEnabled() bool {
+	for _, variable := range []string{"TF_LOG", "TF_LOG_PROVIDER"} {
+		switch strings.ToUpper(os.Getenv(variable)) {
+		case "DEBUG", "TRACE", "JSON":
+			return true
+		}
+	}
+	return false
+}
+
+// loggingTransport logs the method, URL, status, latency and bodies of the requests, with the secrets redacted
+type loggingTransport struct {
+	tokenHeader string
+	next        http.RoundTripper
+}
+
+func (transport *loggingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			requestBody, _ = ioutil.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(request)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	fields := fmt.Sprintf("method=%s url=%s", request.Method, redactURL(request.URL))
+	if err != nil {
+		log.Printf("[DEBUG] Codefresh API request: %s error=%q latency=%s", fields, err, latency)
+		return resp, err
+	}
+	log.Printf("[DEBUG] Codefresh API request: %s status=%d latency=%s headers=%v", fields, resp.StatusCode, latency, transport.redactHeaders(request.Header))
+	if len(requestBody) > 0 {
+		log.Printf("[DEBUG] Codefresh API request body: %s", redactBody(requestBody, request.Header))
+	}
+
+	// the response is buffered to be logged, the large responses are only streamed when the logging is disabled
+	responseBody, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	if readErr != nil {
+		return resp, readErr
+	}
+	if len(responseBody) > 0 {
+		log.Printf("[DEBUG] Codefresh API response body: %s", redactBody(responseBody, resp.Header))
+	}
+
+	return resp, nil
+}
+
+// SetRequestLogging logs the requests of the client at the DEBUG level, with the tokens, the data of the contexts
+// other than config and yaml and the encrypted variables redacted. It must be called after SetRetryPolicy, so that
+// the latency includes the retries
+func (client *Client) SetRequestLogging() {
+	next := client.Client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.Client.Transport = &loggingTransport{
+		tokenHeader: client.TokenHeader,
+		next:        next,
+	}
+}
+
+// isSensitiveName whether the value of the field, header or query parameter must be redacted
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFieldNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func (transport *loggingTransport) redactHeaders(headers http.Header) map[string]string {
+	redacted := map[string]string{}
+	for name := range headers {
+		value := headers.Get(name)
+		if isSensitiveName(name) || strings.EqualFold(name, transport.tokenHeader) {
+			value = redactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+func redactURL(requestURL *url.URL) string {
+	redacted := *requestURL
+	query := redacted.Query()
+	for name := range query {
+		if isSensitiveName(name) {
+			query.Set(name, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// redactBody returns the JSON body with the secrets redacted, the other bodies are only logged by size
+// as they may contain secrets which can't be found
+func redactBody(body []byte, headers http.Header) string {
+	if headers.Get("Content-Encoding") == "gzip" {
+		return fmt.Sprintf("<%d bytes compressed with gzip>", len(body))
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	redacted, err := json.Marshal(redactJSON(value))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	if len(redacted) > maxLoggedBodySize {
+		return fmt.Sprintf("%s... <truncated, %d bytes>", redacted[:maxLoggedBodySize], len(redacted))
+	}
+	return string(redacted)
+}
+
+// redactJSON redacts the sensitive fields, the data of the contexts other than config and yaml and of their encrypted keys,
+// and the values of the encrypted variables
+func redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactJSON(item)
+		}
+		return typed
+	case map[string]interface{}:
+		for name, field := range typed {
+			if isSensitiveName(name) {
+				typed[name] = redactedValue
+				continue
+			}
+			typed[name] = redactJSON(field)
+		}
+
+		if encrypted, _ := typed["encrypted"].(bool); encrypted {
+			if _, ok := typed["value"]; ok {
+				typed["value"] = redactedValue
+			}
+		}
+
+		data, ok := typed["data"].(map[string]interface{})
+		if !ok {
+			return typed
+		}
+		contextType, _ := typed["type"].(string)
+		if contextType != "" && !plainContextTypes[contextType] {
+			for key := range data {
+				data[key] = redactedValue
+			}
+		}
+		if encryptedKeys, ok := typed["encryptedKeys"].([]interface{}); ok {
+			for _, key := range encryptedKeys {
+				if name, ok := key.(string); ok {
+					if _, exists := data[name]; exists {
+						data[name] = redactedValue
+					}
+				}
+			}
+		}
+		return typed
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestClientRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"accessToken": "response-token", "name": "ctx"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	client, err := New(server.URL, WithAPIToken("api-token"), WithRequestLogging())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{
+		"spec": {"type": "config", "data": {"region": "eu", "db_password": "password-value", "api": "encrypted-value"}, "encryptedKeys": ["api"]},
+		"vault": {"spec": {"type": "secret", "data": {"key": "secret-value"}}},
+		"cluster": {"spec": {"type": "kubernetes", "data": {"kubeconfig": "kubeconfig-value"}}},
+		"variables": [{"key": "PLAIN", "value": "plain-value"}, {"key": "KEY", "value": "variable-value", "encrypted": true}]
+	}`)
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/contexts?access_token=query-token", Method: "POST", Body: body}); err != nil {
+		t.Fatal(err)
+	}
+
+	logged := output.String()
+	for _, secret := range []string{"api-token", "query-token", "password-value", "encrypted-value", "secret-value", "kubeconfig-value", "variable-value", "response-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %s to be redacted, got %s", secret, logged)
+		}
+	}
+	for _, expected := range []string{"method=POST", "status=200", "latency=", "plain-value", `"region":"eu"`, `"name":"ctx"`} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("Expected %s to be logged, got %s", expected, logged)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"time"
+)
+
+// clientOptions the settings of the client created by New
+type clientOptions struct {
+	hostV2             string
+	token              string
+	tokenHeader        string
+	additionalHeaders  map[string]string
+	gzipRequests       bool
+	readOnly           bool
+	httpProxy          string
+	httpsProxy         string
+	noProxy            string
+	caCertPEM          string
+	insecureSkipVerify bool
+	requestsPerSecond  float64
+	maxRetries         int
+	retryBaseDelay     time.Duration
+	requestLogging     bool
+}
+
+// Option configures the client created by New
+type Option func(*clientOptions)
+
+// WithAPIToken authenticates the requests with the API key
+func WithAPIToken(token string) Option {
+	return func(options *clientOptions) {
+		options.token = token
+	}
+}
+
+// WithTokenHeader sends the API key in the header instead of Authorization
+func WithTokenHeader(header string) Option {
+	return func(options *clientOptions) {
+		options.tokenHeader = header
+	}
+}
+
+// WithGitopsHost sets the URL of the GitOps API, e.g. https://g.codefresh.io/2.0
+func WithGitopsHost(hostV2 string) Option {
+	return func(options *clientOptions) {
+		options.hostV2 = hostV2
+	}
+}
+
+// WithAdditionalHeaders sends the headers with every request, e.g. for the auth proxies of on-premises installations
+func WithAdditionalHeaders(headers map[string]string) Option {
+	return func(options *clientOptions) {
+		options.additionalHeaders = headers
+	}
+}
+
+// WithGzipRequests compresses the large request bodies
+func WithGzipRequests() Option {
+	return func(options *clientOptions) {
+		options.gzipRequests = true
+	}
+}
+
+// WithReadOnly rejects the requests changing the account, e.g. for the automation which only audits it
+func WithReadOnly() Option {
+	return func(options *clientOptions) {
+		options.readOnly = true
+	}
+}
+
+// WithProxy sends the requests through the proxies, see SetProxy
+func WithProxy(httpProxy string, httpsProxy string, noProxy string) Option {
+	return func(options *clientOptions) {
+		options.httpProxy = httpProxy
+		options.httpsProxy = httpsProxy
+		options.noProxy = noProxy
+	}
+}
+
+// WithCACertificates trusts the PEM encoded CA certificates in addition to the system ones
+func WithCACertificates(caCertPEM string) Option {
+	return func(options *clientOptions) {
+		options.caCertPEM = caCertPEM
+	}
+}
+
+// WithInsecureSkipTLSVerify doesn't verify the certificate of the API, only for testing installations
+func WithInsecureSkipTLSVerify() Option {
+	return func(options *clientOptions) {
+		options.insecureSkipVerify = true
+	}
+}
+
+// WithRateLimit limits the requests per second sent to the host, see SetRateLimit
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(options *clientOptions) {
+		options.requestsPerSecond = requestsPerSecond
+	}
+}
+
+// WithRetryPolicy retries the requests failing with a transient error, see SetRetryPolicy
+func WithRetryPolicy(maxRetries int, baseDelay time.Duration) Option {
+	return func(options *clientOptions) {
+		options.maxRetries = maxRetries
+		options.retryBaseDelay = baseDelay
+	}
+}
+
+// WithRequestLogging logs the requests at the DEBUG level with the secrets redacted, see SetRequestLogging.
+// The requests are also logged when TF_LOG or TF_LOG_PROVIDER is DEBUG or TRACE
+func WithRequestLogging() Option {
+	return func(options *clientOptions) {
+		options.requestLogging = true
+	}
+}
+
+// New returns a client of the API at host, e.g. https://g.codefresh.io/api, configured with the options.
+// Unlike the setters of the client, the options can be given in any order
+func New(host string, opts ...Option) (*Client, error) {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	client := NewClient(host, options.hostV2, options.token, options.tokenHeader)
+	client.GzipRequests = options.gzipRequests
+	client.ReadOnly = options.readOnly
+	if len(options.additionalHeaders) > 0 {
+		client.AdditionalHeaders = map[string]string{}
+		for name, value := range options.additionalHeaders {
+			client.AdditionalHeaders[name] = value
+		}
+	}
+
+	// the transport settings first, then the rate limit, the retries and the logging wrapping the transport
+	err := client.SetProxy(options.httpProxy, options.httpsProxy, options.noProxy)
+	if err != nil {
+		return nil, err
+	}
+	err = client.SetTLSConfig(options.caCertPEM, options.insecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	client.SetRateLimit(options.requestsPerSecond)
+	client.SetRetryPolicy(options.maxRetries, options.retryBaseDelay)
+	if options.requestLogging || RequestLoggingEnabled() {
+		client.SetRequestLogging()
+	}
+
+	return client, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientOptions(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL,
+		WithAPIToken("token"),
+		WithAdditionalHeaders(map[string]string{"X-Tenant": "acme"}),
+		WithRetryPolicy(1, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.RequestAPI(&RequestOptions{Path: "/projects/unknown", Method: "GET"})
+	if headers.Get("Authorization") != "token" || headers.Get("X-Tenant") != "acme" {
+		t.Errorf("Expected the token and the additional headers to be sent, got %v", headers)
+	}
+	if !IsNotFoundError(fmt.Errorf("reading the project: %w", err)) {
+		t.Errorf("Expected a wrapped not found error, got %v", err)
+	}
+
+	if _, err := New(server.URL, WithCACertificates("not a certificate")); err == nil {
+		t.Error("Expected an error for an invalid CA certificate")
+	}
+}
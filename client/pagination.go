@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// defaultPageSize the number of items requested by page
+const defaultPageSize = 100
+
+// maxPages stops following the pages of a list which never ends, e.g. an endpoint always returning a cursor
+const maxPages = 10000
+
+// pageResponse the paginated responses of the API, the items are in docs or items, with the total count of the
+// items or a cursor of the next page
+type pageResponse struct {
+	Docs  json.RawMessage `json:"docs"`
+	Items json.RawMessage `json:"items"`
+	Count *int            `json:"count"`
+	Total *int            `json:"total"`
+	Next  string          `json:"next"`
+}
+
+// decodePage returns the items of the page and the cursor of the next page, the page is either an array of items
+// or an object wrapping them
+func decodePage(body []byte) ([]json.RawMessage, *pageResponse, error) {
+	page := &pageResponse{}
+	rawItems := bytes.TrimSpace(body)
+	if len(rawItems) == 0 || rawItems[0] != '[' {
+		err := json.Unmarshal(body, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		rawItems = page.Docs
+		if len(rawItems) == 0 {
+			rawItems = page.Items
+		}
+	}
+
+	var items []json.RawMessage
+	if len(rawItems) > 0 && string(rawItems) != "null" {
+		err := json.Unmarshal(rawItems, &items)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return items, page, nil
+}
+
+// RequestAllPages sends the list request page by page and decodes the items of all the pages into target,
+// a pointer to a slice. The pages are requested with limit and offset, or with the next cursor when the API
+// returns one. The endpoints which aren't paginated return all the items in the first page: the following
+// request returns the same page and the pagination stops
+func (client *Client) RequestAllPages(opt *RequestOptions, target interface{}) error {
+	var allItems []json.RawMessage
+	var previousPage []byte
+	offset := 0
+	cursor := ""
+
+	for pages := 0; pages < maxPages; pages++ {
+		qs := map[string]string{}
+		for key, value := range opt.QS {
+			qs[key] = value
+		}
+		qs["limit"] = strconv.Itoa(defaultPageSize)
+		if cursor != "" {
+			qs["next"] = cursor
+		} else {
+			qs["offset"] = strconv.Itoa(offset)
+		}
+
+		pageOpt := *opt
+		pageOpt.QS = qs
+		body, err := client.RequestAPI(&pageOpt)
+		if err != nil {
+			return err
+		}
+		if previousPage != nil && bytes.Equal(body, previousPage) {
+			break
+		}
+		previousPage = body
+
+		items, page, err := decodePage(body)
+		if err != nil {
+			return fmt.Errorf("failed to decode the page of %s at offset %d: %v", opt.Path, offset, err)
+		}
+		allItems = append(allItems, items...)
+		offset += len(items)
+
+		if len(items) == 0 {
+			break
+		}
+		if page.Next != "" {
+			cursor = page.Next
+			continue
+		}
+		total := page.Count
+		if total == nil {
+			total = page.Total
+		}
+		if len(items) < defaultPageSize || (total != nil && offset >= *total) {
+			break
+		}
+	}
+
+	if allItems == nil {
+		allItems = []json.RawMessage{}
+	}
+	body, err := json.Marshal(allItems)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestClientRequestAllPages(t *testing.T) {
+	// 250 items served by 3 pages of 100 items
+	items := make([]string, 250)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"_id": "%d"}`, i)
+	}
+	page := func(r *http.Request) []string {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if offset > len(items) {
+			offset = len(items)
+		}
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[offset:end]
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/array", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s]", strings.Join(page(r), ","))
+	})
+	mux.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"docs": [%s], "count": %d}`, strings.Join(page(r), ","), len(items))
+	})
+	mux.HandleFunc("/cursor", func(w http.ResponseWriter, r *http.Request) {
+		// the cursor is the offset of the next page
+		offset, _ := strconv.Atoi(r.URL.Query().Get("next"))
+		end := offset + 100
+		next := strconv.Itoa(end)
+		if end >= len(items) {
+			end, next = len(items), ""
+		}
+		fmt.Fprintf(w, `{"items": [%s], "next": "%s"}`, strings.Join(items[offset:end], ","), next)
+	})
+	mux.HandleFunc("/unpaginated", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "token", "")
+	for _, path := range []string{"/array", "/docs", "/cursor", "/unpaginated"} {
+		var decoded []struct {
+			ID string `json:"_id"`
+		}
+		err := client.RequestAllPages(&RequestOptions{Path: path, Method: "GET"}, &decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != len(items) || decoded[len(items)-1].ID != "249" {
+			t.Errorf("%s: expected the %d items of all the pages, got %d", path, len(items), len(decoded))
+		}
+	}
+}
@@ -5,6 +5,20 @@ import (
 	//"log"
 )
 
+// The types of resources supported by the permissions
+const (
+	PermissionResourcePipeline           = "pipeline"
+	PermissionResourceCluster            = "cluster"
+	PermissionResourceRuntimeEnvironment = "runtime-environment"
+)
+
+// PermissionResources the types of resources supported by the permissions
+var PermissionResources = []string{
+	PermissionResourcePipeline,
+	PermissionResourceCluster,
+	PermissionResourceRuntimeEnvironment,
+}
+
 // Permission spec
 type Permission struct {
 	ID       string   `json:"id,omitempty"`
@@ -33,15 +47,8 @@ func (client *Client) GetPermissionList(teamID, action, resource string) ([]Perm
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var permissions, permissionsFiltered []Permission
-
-	err = DecodeResponseInto(resp, &permissions)
+	err := client.RequestAllPages(&opts, &permissions)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,8 @@ package client
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -16,15 +18,24 @@ type Labels struct {
 	Tags []string `json:"tags,omitempty"`
 }
 
+// PipelineTemplate marks the golden template pipelines and references the template of the pipelines created from one
+type PipelineTemplate struct {
+	IsTemplate    bool   `json:"isTemplate"`
+	GeneratedFrom string `json:"generatedFrom,omitempty"`
+}
+
 type Metadata struct {
-	Name               string `json:"name,omitempty"`
-	ID                 string `json:"id,omitempty"`
-	IsPublic           bool   `json:"isPublic,omitempty"`
-	Labels             Labels `json:"labels,omitempty"`
-	OriginalYamlString string `json:"originalYamlString,omitempty"`
-	Project            string `json:"project,omitempty"`
-	ProjectId          string `json:"projectId,omitempty"`
-	Revision           int    `json:"revision,omitempty"`
+	Name               string            `json:"name,omitempty"`
+	ID                 string            `json:"id,omitempty"`
+	IsPublic           bool              `json:"isPublic,omitempty"`
+	Labels             Labels            `json:"labels,omitempty"`
+	OriginalYamlString string            `json:"originalYamlString,omitempty"`
+	Project            string            `json:"project,omitempty"`
+	ProjectId          string            `json:"projectId,omitempty"`
+	Revision           int               `json:"revision,omitempty"`
+	Scopes             []string          `json:"scopes,omitempty"`
+	Template           *PipelineTemplate `json:"template,omitempty"`
+	UpdatedAt          string            `json:"updated_at,omitempty"`
 }
 
 type SpecTemplate struct {
@@ -49,11 +60,39 @@ type Trigger struct {
 	Provider                     string              `json:"provider,omitempty"`
 	Disabled                     bool                `json:"disabled,omitempty"`
 	PullRequestAllowForkEvents   bool                `json:"pullRequestAllowForkEvents,omitempty"`
+	PullRequestHeadRepoRegex     string              `json:"pullRequestHeadRepoRegex,omitempty"`
+	PullRequestForkExposeSecrets bool                `json:"pullRequestForkExposeSecrets"`
 	CommitStatusTitle            string              `json:"commitStatusTitle,omitempty"`
+	CommitMessageRegex           string              `json:"commitMessageRegex,omitempty"`
+	SkipCICheck                  bool                `json:"skipCICheck,omitempty"`
 	Context                      string              `json:"context,omitempty"`
 	Contexts                     []string            `json:"contexts,omitempty"`
 	RuntimeEnvironment           *RuntimeEnvironment `json:"runtimeEnvironment,omitempty"`
 	Variables                    []Variable          `json:"variables,omitempty"`
+	SourcePipelineID             string              `json:"sourcePipelineId,omitempty"`
+	Options                      *TriggerOptions     `json:"options,omitempty"`
+	Annotations                  map[string]string   `json:"annotations,omitempty"`
+}
+
+// TriggerOptions the build options of the builds created by the trigger
+type TriggerOptions struct {
+	NoCache             bool `json:"noCache"`
+	NoCfCache           bool `json:"noCfCache"`
+	ResetVolume         bool `json:"resetVolume"`
+	EnableNotifications bool `json:"enableNotifications"`
+}
+
+// CronTrigger runs the pipeline on a schedule, the expression is evaluated in UTC: the API has no timezone, the
+// provider's cron_trigger timezone is a fixed offset applied to the expression at the time of the apply
+type CronTrigger struct {
+	Name         string     `json:"name,omitempty"`
+	Type         string     `json:"type,omitempty"`
+	Expression   string     `json:"expression,omitempty"`
+	Message      string     `json:"message,omitempty"`
+	Disabled     bool       `json:"disabled,omitempty"`
+	GitTriggerID string     `json:"gitTriggerId,omitempty"`
+	Branch       string     `json:"branch,omitempty"`
+	Variables    []Variable `json:"variables,omitempty"`
 }
 
 type RuntimeEnvironment struct {
@@ -69,10 +108,18 @@ func (t *Trigger) SetVariables(variables map[string]interface{}) {
 	}
 }
 
+// SetEncryptedVariables adds the variables stored encrypted by the API
+func (t *Trigger) SetEncryptedVariables(variables map[string]interface{}) {
+	for key, value := range variables {
+		t.Variables = append(t.Variables, Variable{Key: key, Value: value.(string), Encrypted: true})
+	}
+}
+
 type Spec struct {
 	Variables          []Variable               `json:"variables,omitempty"`
 	SpecTemplate       *SpecTemplate            `json:"specTemplate,omitempty"`
 	Triggers           []Trigger                `json:"triggers,omitempty"`
+	CronTriggers       []CronTrigger            `json:"cronTriggers,omitempty"`
 	Priority           int                      `json:"priority,omitempty"`
 	Concurrency        int                      `json:"concurrency,omitempty"`
 	BranchConcurrency  int                      `json:"branchConcurrency,omitempty"`
@@ -82,6 +129,8 @@ type Spec struct {
 	Stages             *Stages                  `json:"stages,omitempty"`
 	Mode               string                   `json:"mode,omitempty"`
 	FailFast           *bool                    `json:"fail_fast,omitempty"`
+	ProtectVariables   *bool                    `json:"protectVariables,omitempty"`
+	ExecutionContext   string                   `json:"executionContext,omitempty"`
 	RuntimeEnvironment RuntimeEnvironment       `json:"runtimeEnvironment,omitempty"`
 	TerminationPolicy  []map[string]interface{} `json:"terminationPolicy,omitempty"`
 	Hooks              *Hooks                   `json:"hooks,omitempty"`
@@ -132,6 +181,13 @@ type Pipeline struct {
 	Version  string   `json:"version,omitempty"`
 }
 
+// PipelinesFilter server side filters for the pipelines list, empty fields are ignored
+type PipelinesFilter struct {
+	Name      string
+	ProjectID string
+	Labels    []string
+}
+
 func (p *Pipeline) SetVariables(variables map[string]interface{}) {
 	for key, value := range variables {
 		p.Spec.Variables = append(p.Spec.Variables, Variable{Key: key, Value: value.(string)})
@@ -147,26 +203,131 @@ func (pipeline *Pipeline) GetID() string {
 }
 
 func (client *Client) GetPipeline(name string) (*Pipeline, error) {
+	return client.GetPipelineWithDecrypt(name, false)
+}
+
+// GetPipelineWithDecrypt returns the pipeline, with the values of the encrypted variables of the spec and of the
+// triggers when decrypt is set. The API masks them otherwise, so the pipelines read to be sent back with an update
+// must be read with decrypt, or the masked values would overwrite the secrets
+func (client *Client) GetPipelineWithDecrypt(name string, decrypt bool) (*Pipeline, error) {
 	fullPath := fmt.Sprintf("/pipelines/%s", strings.Replace(name, "/", "%2F", 1))
 	opts := RequestOptions{
 		Path:   fullPath,
 		Method: "GET",
 	}
+	if decrypt {
+		opts.QS = map[string]string{"decryptVariables": "true"}
+	}
 
-	resp, err := client.RequestAPI(&opts)
+	var pipeline Pipeline
 
+	err := client.RequestAPIInto(&opts, &pipeline)
 	if err != nil {
 		return nil, err
 	}
 
-	var pipeline Pipeline
+	return &pipeline, nil
+}
 
-	err = DecodeResponseInto(resp, &pipeline)
+// GetPipelineUnknownSpecFields returns the fields set in the spec of the pipeline which aren't supported by Spec,
+// i.e. the settings of the pipeline which are ignored by the client
+func (client *Client) GetPipelineUnknownSpecFields(name string) ([]string, error) {
+	fullPath := fmt.Sprintf("/pipelines/%s", strings.Replace(name, "/", "%2F", 1))
+	opts := RequestOptions{
+		Path:   fullPath,
+		Method: "GET",
+	}
+
+	var pipeline struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+
+	err := client.RequestAPIInto(&opts, &pipeline)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pipeline, nil
+	knownFields := map[string]bool{}
+	specType := reflect.TypeOf(Spec{})
+	for i := 0; i < specType.NumField(); i++ {
+		knownFields[strings.Split(specType.Field(i).Tag.Get("json"), ",")[0]] = true
+	}
+
+	var unknownFields []string
+	for field, value := range pipeline.Spec {
+		if !knownFields[field] && !isEmptyJSONValue(value) {
+			unknownFields = append(unknownFields, field)
+		}
+	}
+	sort.Strings(unknownFields)
+
+	return unknownFields, nil
+}
+
+func isEmptyJSONValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// GetPipelines returns the pipelines matching the filter
+func (client *Client) GetPipelines(filter *PipelinesFilter) ([]Pipeline, error) {
+	qs := map[string]string{}
+	if filter != nil {
+		if filter.Name != "" {
+			qs["name"] = filter.Name
+		}
+		if filter.ProjectID != "" {
+			qs["projectId"] = filter.ProjectID
+		}
+		if len(filter.Labels) > 0 {
+			qs["labels"] = strings.Join(filter.Labels, ",")
+		}
+	}
+
+	opts := RequestOptions{
+		Path:   "/pipelines",
+		Method: "GET",
+	}
+	if len(qs) > 0 {
+		opts.QS = qs
+	}
+
+	var pipelines []Pipeline
+
+	err := client.RequestAllPages(&opts, &pipelines)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelines, nil
+}
+
+// GetPipelineByName returns the pipeline with the exact full name, e.g. project/pipeline
+func (client *Client) GetPipelineByName(name string) (*Pipeline, error) {
+
+	if name == "" {
+		return nil, fmt.Errorf("GetPipelineByName - must specify name param")
+	}
+
+	pipelines, err := client.GetPipelines(&PipelinesFilter{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Metadata.Name == name {
+			return &pipeline, nil
+		}
+	}
+
+	return nil, fmt.Errorf("GetPipelineByName - cannot find pipeline by name %s", name)
 }
 
 func (client *Client) CreatePipeline(pipeline *Pipeline) (*Pipeline, error) {
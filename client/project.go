@@ -11,6 +11,10 @@ type Project struct {
 	ProjectName string     `json:"projectName,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
 	Variables   []Variable `json:"variables,omitempty"`
+	// Description, Icon and Color are always sent, so that an update clears the values removed from the configuration
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Color       string `json:"color"`
 }
 
 // GetID implement CodefreshObject interface
@@ -131,6 +135,28 @@ func (client *Client) UpdateProject(project *Project) error {
 	return nil
 }
 
+// UpdateProjectVariables replaces the variables of the project, an empty list removes all the variables
+func (client *Client) UpdateProjectVariables(id string, variables []Variable) error {
+	if variables == nil {
+		variables = []Variable{}
+	}
+
+	body, err := EncodeToJSON(map[string]interface{}{"variables": variables})
+	if err != nil {
+		return err
+	}
+
+	fullPath := fmt.Sprintf("/projects/%s", id)
+	opts := RequestOptions{
+		Path:   fullPath,
+		Method: "PATCH",
+		Body:   body,
+	}
+
+	_, err = client.RequestAPI(&opts)
+	return err
+}
+
 // DeleteProject DELETE
 func (client *Client) DeleteProject(id string) error {
 	fullPath := fmt.Sprintf("/projects/%s", id)
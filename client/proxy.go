@@ -0,0 +1,52 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// SetProxy sends the requests of the client through the proxies, httpProxy and httpsProxy override the HTTP_PROXY
+// and HTTPS_PROXY environment variables for the http and https URLs and noProxy the NO_PROXY one, the hosts
+// matching NO_PROXY are reached directly. It must be called before SetRateLimit, which wraps the transport of the client
+func (client *Client) SetProxy(httpProxy string, httpsProxy string, noProxy string) error {
+	config := httpproxy.FromEnvironment()
+	for _, proxyURL := range []string{httpProxy, httpsProxy} {
+		if proxyURL == "" {
+			continue
+		}
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy URL %s, expected e.g. http://proxy.example.com:3128", proxyURL)
+		}
+	}
+	if httpProxy != "" {
+		config.HTTPProxy = httpProxy
+	}
+	if httpsProxy != "" {
+		config.HTTPSProxy = httpsProxy
+	}
+	if noProxy != "" {
+		config.NoProxy = noProxy
+	}
+
+	proxyFunc := config.ProxyFunc()
+	transport := client.httpTransport()
+	transport.Proxy = func(request *http.Request) (*url.URL, error) {
+		return proxyFunc(request.URL)
+	}
+	return nil
+}
+
+// httpTransport returns the transport of the client, set to a copy of the default one on first use
+// so that the proxy and TLS settings don't change the transport shared by the process
+func (client *Client) httpTransport() *http.Transport {
+	if transport, ok := client.Client.Transport.(*http.Transport); ok {
+		return transport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client.Client.Transport = transport
+	return transport
+}
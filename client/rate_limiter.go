@@ -0,0 +1,107 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter token bucket limiting the rate of the requests, the requests over the rate wait for their turn
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// sharedRateLimiters the rate limiters by host, shared by all the clients of the host,
+// e.g. the provider aliases configured with the same API URL
+var sharedRateLimiters sync.Map
+
+// SharedRateLimiter returns the rate limiter of the host, when the clients of the host are configured
+// with different rates the lowest one is used
+func SharedRateLimiter(host string, requestsPerSecond float64) *RateLimiter {
+	limiter, _ := sharedRateLimiters.LoadOrStore(host, &RateLimiter{
+		rate:   requestsPerSecond,
+		tokens: requestsPerSecond,
+		last:   time.Now(),
+	})
+	rateLimiter := limiter.(*RateLimiter)
+
+	rateLimiter.mu.Lock()
+	defer rateLimiter.mu.Unlock()
+	if requestsPerSecond < rateLimiter.rate {
+		rateLimiter.rate = requestsPerSecond
+	}
+
+	return rateLimiter
+}
+
+// Rate returns the requests per second allowed by the rate limiter
+func (limiter *RateLimiter) Rate() float64 {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.rate
+}
+
+// reserve takes a token and returns how long the request must wait for it. The tokens go negative
+// when the bucket is empty, so the waiting requests are spread at the rate instead of being sent together
+func (limiter *RateLimiter) reserve() time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	// the burst is the requests of a second
+	burst := limiter.rate
+	if burst < 1 {
+		burst = 1
+	}
+
+	now := time.Now()
+	limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.rate
+	if limiter.tokens > burst {
+		limiter.tokens = burst
+	}
+	limiter.last = now
+
+	limiter.tokens--
+	if limiter.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+}
+
+// rateLimitedTransport waits for the rate limiter of the host before sending each request
+type rateLimitedTransport struct {
+	requestsPerSecond float64
+	next              http.RoundTripper
+}
+
+func (transport *rateLimitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	wait := SharedRateLimiter(request.URL.Host, transport.requestsPerSecond).reserve()
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+	}
+	return transport.next.RoundTrip(request)
+}
+
+// SetRateLimit limits the requests sent by the client to requestsPerSecond, the limit is shared by all the
+// clients sending requests to the same host. A zero or negative rate doesn't limit the requests
+func (client *Client) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		return
+	}
+	next := client.Client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.Client.Transport = &rateLimitedTransport{
+		requestsPerSecond: requestsPerSecond,
+		next:              next,
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import "testing"
+
+func TestSharedRateLimiter(t *testing.T) {
+	alias := SharedRateLimiter("rate-limiter.test", 10)
+
+	// the lowest rate of the aliases of the host is used
+	if limiter := SharedRateLimiter("rate-limiter.test", 5); limiter != alias || limiter.Rate() != 5 {
+		t.Errorf("Expected the rate limiter of the host to be shared with the rate 5, got %v", limiter.Rate())
+	}
+	if limiter := SharedRateLimiter("rate-limiter.test", 20); limiter != alias || limiter.Rate() != 5 {
+		t.Errorf("Expected the rate limiter of the host to keep the rate 5, got %v", limiter.Rate())
+	}
+	if limiter := SharedRateLimiter("other-host.test", 20); limiter == alias || limiter.Rate() != 20 {
+		t.Errorf("Expected a separate rate limiter for another host")
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryDelay caps the delay between two attempts of a request
+const maxRetryDelay = 30 * time.Second
+
+// retryTransport retries the requests failing with a network error or a transient status, the delay
+// between the attempts doubles from baseDelay, unless the API asks for a delay with Retry-After
+type retryTransport struct {
+	maxRetries int
+	baseDelay  time.Duration
+	next       http.RoundTripper
+}
+
+// isIdempotentMethod whether the request can be sent again when the API may have processed it
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// shouldRetry whether the attempt failed with a transient error. The requests creating or patching entities
+// are only retried when the API didn't process them, i.e. it rate limited them or was unavailable, so that they
+// don't create duplicates
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := isIdempotentMethod(method)
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// gatewayRetryWeight multiplies the delay before retrying a request which timed out behind a gateway,
+// the API is likely still busy with the previous attempt
+const gatewayRetryWeight = 3
+
+func (transport *retryTransport) retryDelay(attempt int, resp *http.Response) time.Duration {
+	weight := 1.0
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusGatewayTimeout {
+			weight = gatewayRetryWeight
+		}
+	}
+	delay := time.Duration(weight * float64(transport.baseDelay) * math.Pow(2, float64(attempt)))
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// RoundTrip sends the request and its retries. A RoundTripper must not modify the request, so the retries are sent
+// as clones of the request with a new body
+func (transport *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	attemptRequest := request
+	for attempt := 0; ; attempt++ {
+		resp, err := transport.next.RoundTrip(attemptRequest)
+		if attempt >= transport.maxRetries || !shouldRetry(request.Method, resp, err) || (request.Body != nil && request.GetBody == nil) {
+			return resp, err
+		}
+
+		delay := transport.retryDelay(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed, retrying in %s. Error = %v", request.Method, request.URL.Path, delay, err)
+		} else {
+			log.Printf("[DEBUG] %s %s failed with %s, retrying in %s", request.Method, request.URL.Path, resp.Status, delay)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		}
+
+		attemptRequest = request.Clone(request.Context())
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptRequest.Body = body
+		}
+	}
+}
+
+// SetRetryPolicy retries the requests failing with a transient error up to maxRetries times, with an exponential
+// backoff from baseDelay. It must be called after SetRateLimit, so that the retries are rate limited too.
+// Zero retries disable it
+func (client *Client) SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	if maxRetries <= 0 {
+		return
+	}
+	next := client.Client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.Client.Transport = &retryTransport{
+		maxRetries: maxRetries,
+		baseDelay:  baseDelay,
+		next:       next,
+	}
+}
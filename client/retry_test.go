@@ -0,0 +1,104 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "token", "")
+	client.SetRetryPolicy(3, time.Millisecond)
+
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/projects", Method: "GET"}); err != nil {
+		t.Errorf("Expected the request to succeed after the retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+
+	attempts = 3
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/projects", Method: "POST", Body: []byte("{}")}); err == nil {
+		t.Errorf("Expected the create to fail without retry on 502")
+	}
+	if attempts != 4 {
+		t.Errorf("Expected a single attempt of the create, got %d", attempts-3)
+	}
+}
+
+func TestClientIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "token", "")
+	client.SetRetryPolicy(1, time.Millisecond)
+
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/pipelines", Method: "POST", Body: []byte("{}")}); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the retry to send the same idempotency key, got %v", keys)
+	}
+}
+
+// roundTripFunc mocks the transport of the retries
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestRetryTransportKeepsRequest(t *testing.T) {
+	var bodies []string
+	transport := &retryTransport{maxRetries: 1, baseDelay: time.Millisecond, next: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		status := http.StatusOK
+		if len(bodies) == 1 {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	})}
+
+	request, err := http.NewRequest("PUT", "https://g.codefresh.io/api/pipelines/id", strings.NewReader(`{"spec":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := request.Body
+
+	if _, err := transport.RoundTrip(request); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"spec":{}}` {
+		t.Errorf("Expected the retry to send the whole body again, got %q", bodies)
+	}
+	if request.Body != body {
+		t.Error("Expected the request of the caller to be kept")
+	}
+}
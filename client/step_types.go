@@ -43,13 +43,8 @@ func (client *Client) GetStepTypesVersions(name string) ([]string, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
 	var respStepTypesVersions []string
-	err = DecodeResponseInto(resp, &respStepTypesVersions)
+	err := client.RequestAllPages(&opts, &respStepTypesVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -42,15 +42,8 @@ func (client *Client) GetTeamList() ([]Team, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-
-	if err != nil {
-		return nil, err
-	}
-
 	var teams []Team
-
-	err = DecodeResponseInto(resp, &teams)
+	err := client.RequestAllPages(&opts, &teams)
 	if err != nil {
 		return nil, err
 	}
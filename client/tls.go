@@ -0,0 +1,43 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// SetTLSConfig trusts the PEM encoded CA certificates in addition to the system ones, e.g. for the internal CAs
+// of on-premises installations, and disables the verification of the server certificate when insecureSkipVerify is set.
+// It must be called before SetRateLimit, which wraps the transport of the client
+func (client *Client) SetTLSConfig(caCertPEM string, insecureSkipVerify bool) error {
+	if caCertPEM == "" && !insecureSkipVerify {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+	if caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return fmt.Errorf("no valid PEM encoded certificate found in the CA certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	client.httpTransport().TLSClientConfig = tlsConfig
+	return nil
+}
+
+// ReadCACertFile returns the content of the PEM file of CA certificates
+func ReadCACertFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the CA certificates file %s: %v", path, err)
+	}
+	return string(content), nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCACertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "token", "")
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/projects", Method: "GET"}); err == nil {
+		t.Fatal("Expected the request to fail with an unknown CA")
+	}
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	client = NewClient(server.URL, "", "token", "")
+	if err := client.SetTLSConfig(caCertPEM, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RequestAPI(&RequestOptions{Path: "/projects", Method: "GET"}); err != nil {
+		t.Errorf("Expected the request to succeed with the CA certificate, got %v", err)
+	}
+
+	if err := client.SetTLSConfig("not a certificate", false); err == nil {
+		t.Error("Expected an error for an invalid CA certificate")
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"fmt"
-	"log"
 )
 
 type Credentials struct {
@@ -57,6 +56,13 @@ type NewUser struct {
 	Personal *Personal `json:"personal,omitempty"`
 }
 
+// UserInvitation the invitation email sent to a pending user
+type UserInvitation struct {
+	UserID  string `json:"userId"`
+	Message string `json:"message,omitempty"`
+	IdpID   string `json:"idpId,omitempty"`
+}
+
 type UserAccounts struct {
 	UserName string    `json:"userName"`
 	Account  []Account `json:"account"`
@@ -133,7 +139,10 @@ func (client *Client) AddUserToTeamByAdmin(userID string, accountID string, team
 		return err
 	}
 	// new Client for accountAdmin
-	accountAdminClient := NewClient(client.Host, accountAdminToken, "x-access-token")
+	accountAdminClient := NewClient(client.Host, client.HostV2, accountAdminToken, "x-access-token")
+	accountAdminClient.ReadOnly = client.ReadOnly
+	accountAdminClient.AdditionalHeaders = client.AdditionalHeaders
+	accountAdminClient.Client = client.Client
 	usersTeam, err := accountAdminClient.GetTeamByName(team)
 	if err != nil {
 		return err
@@ -178,6 +187,28 @@ func (client *Client) ActivateUser(userId string) (*User, error) {
 	return &user, nil
 }
 
+// SendUserInvitation sends the invitation email of the account to the pending user
+func (client *Client) SendUserInvitation(accountID string, invitation *UserInvitation) error {
+
+	body, err := EncodeToJSON(invitation)
+	if err != nil {
+		return err
+	}
+
+	opts := RequestOptions{
+		Path:   fmt.Sprintf("/admin/accounts/%s/invite", accountID),
+		Method: "POST",
+		Body:   body,
+	}
+
+	_, err = client.RequestAPI(&opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (client *Client) SetUserAsAccountAdmin(accountId, userId string) error {
 
 	opts := RequestOptions{
@@ -215,15 +246,8 @@ func (client *Client) GetAllUsers() (*[]User, error) {
 		Method: "GET",
 	}
 
-	resp, err := client.RequestAPI(&opts)
-	if err != nil {
-		return nil, err
-	}
-
 	var users []User
-	respStr := string(resp)
-	log.Printf("[INFO] GetAllUsers resp: %s", respStr)
-	err = DecodeResponseInto(resp, &users)
+	err := client.RequestAllPages(&opts, &users)
 	if err != nil {
 		return nil, err
 	}
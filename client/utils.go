@@ -1,9 +1,55 @@
 package client
 
+import (
+	"encoding/json"
+	"log"
+	"strings"
+)
+
 // Variable spec
 type Variable struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Encrypted   bool   `json:"encrypted,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// UnmarshalJSON decodes the variable, the values which aren't strings, e.g. numbers, are kept as their
+// JSON text with a warning, instead of failing to read the whole entity
+func (variable *Variable) UnmarshalJSON(data []byte) error {
+	type plainVariable Variable
+	var raw struct {
+		plainVariable
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*variable = Variable(raw.plainVariable)
+	variable.Value = DecodeDriftedString("variable "+variable.Key, raw.Value)
+	return nil
+}
+
+// DecodeDriftedString decodes a JSON value expected to be a string, the other values are returned as their
+// JSON text and logged as a drift of the API response from the schema of the provider
+func DecodeDriftedString(field string, value json.RawMessage) string {
+	text := strings.TrimSpace(string(value))
+	if text == "" || text == "null" {
+		return ""
+	}
+	var decoded string
+	if err := json.Unmarshal(value, &decoded); err == nil {
+		return decoded
+	}
+	WarnResponseDrift(field, text, "string")
+	return text
+}
+
+// WarnResponseDrift logs a value of the API response with an unexpected type, the server may be newer than
+// the provider
+func WarnResponseDrift(field string, value interface{}, expectedType string) {
+	log.Printf("[WARN] The API returned the %s with the unexpected value %v, expected a %s. The value is converted, the provider may be outdated", field, value, expectedType)
 }
 
 // CodefreshObject codefresh interface
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// WaitPollInterval delay between two checks of the state of an entity
+var WaitPollInterval = 5 * time.Second
+
+// WaitFor polls isReady until it returns true, returns an error when isReady fails or the timeout expires
+func WaitFor(description string, timeout time.Duration, isReady func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ready, err := isReady()
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+		if time.Now().Add(WaitPollInterval).After(deadline) {
+			return fmt.Errorf("[ERROR] Timeout after %s waiting for %s to be ready", timeout, description)
+		}
+		log.Printf("[DEBUG] Waiting for %s to be ready", description)
+		time.Sleep(WaitPollInterval)
+	}
+}
+
+// WaitForAccountReady waits until the account created asynchronously can be read
+func (client *Client) WaitForAccountReady(id string, timeout time.Duration) error {
+	return WaitFor(fmt.Sprintf("account %s", id), timeout, func() (bool, error) {
+		account, err := client.GetAccountByID(id)
+		if IsNotFoundError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return account.ID == id, nil
+	})
+}
+
+// WaitForWorkflowTemplateReady waits until the workflow template committed to git is synced in the runtime
+func (client *Client) WaitForWorkflowTemplateReady(runtime, name string, timeout time.Duration) error {
+	return WaitFor(fmt.Sprintf("workflow template %s/%s", runtime, name), timeout, func() (bool, error) {
+		template, err := client.GetWorkflowTemplate(runtime, name)
+		if err != nil {
+			return false, err
+		}
+		return template != nil, nil
+	})
+}
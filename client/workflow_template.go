@@ -0,0 +1,120 @@
+package client
+
+import (
+	"fmt"
+)
+
+// WorkflowTemplateMetadata identifies the workflow template in a GitOps runtime
+type WorkflowTemplateMetadata struct {
+	Name    string `json:"name"`
+	Runtime string `json:"runtime"`
+}
+
+// WorkflowTemplateSource git source of the workflow template manifest
+type WorkflowTemplateSource struct {
+	RepoURL  string `json:"repoURL"`
+	Revision string `json:"revision,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// WorkflowTemplate spec
+type WorkflowTemplate struct {
+	Metadata WorkflowTemplateMetadata `json:"metadata"`
+	Source   WorkflowTemplateSource   `json:"source"`
+}
+
+// GetID implement CodefreshObject interface
+func (template *WorkflowTemplate) GetID() string {
+	return fmt.Sprintf("%s/%s", template.Metadata.Runtime, template.Metadata.Name)
+}
+
+const workflowTemplateFields = `
+	metadata {
+		name
+		runtime
+	}
+	source {
+		repoURL
+		revision
+		path
+	}`
+
+// GetWorkflowTemplate get the workflow template by its runtime and name, returns nil when it doesn't exist
+func (client *Client) GetWorkflowTemplate(runtime, name string) (*WorkflowTemplate, error) {
+	request := GraphQLRequest{
+		Query: `query WorkflowTemplate($runtime: String!, $name: String!) {
+			workflowTemplate(runtime: $runtime, name: $name) {` + workflowTemplateFields + `
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"runtime": runtime,
+			"name":    name,
+		},
+	}
+
+	var resp struct {
+		WorkflowTemplate *WorkflowTemplate `json:"workflowTemplate"`
+	}
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.WorkflowTemplate, nil
+}
+
+// CreateWorkflowTemplate registers the workflow template in the runtime
+func (client *Client) CreateWorkflowTemplate(template *WorkflowTemplate) (*WorkflowTemplate, error) {
+	request := GraphQLRequest{
+		Query: `mutation CreateWorkflowTemplate($args: WorkflowTemplateInput!) {
+			createWorkflowTemplate(args: $args) {` + workflowTemplateFields + `
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"args": template,
+		},
+	}
+
+	var resp struct {
+		CreateWorkflowTemplate *WorkflowTemplate `json:"createWorkflowTemplate"`
+	}
+	err := client.SendGqlRequest(&request, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CreateWorkflowTemplate, nil
+}
+
+// UpdateWorkflowTemplate updates the source of the workflow template
+func (client *Client) UpdateWorkflowTemplate(template *WorkflowTemplate) error {
+	request := GraphQLRequest{
+		Query: `mutation UpdateWorkflowTemplate($args: WorkflowTemplateInput!) {
+			updateWorkflowTemplate(args: $args) {
+				metadata {
+					name
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{
+			"args": template,
+		},
+	}
+
+	return client.SendGqlRequest(&request, nil)
+}
+
+// DeleteWorkflowTemplate removes the workflow template from the runtime
+func (client *Client) DeleteWorkflowTemplate(runtime, name string) error {
+	request := GraphQLRequest{
+		Query: `mutation DeleteWorkflowTemplate($runtime: String!, $name: String!) {
+			deleteWorkflowTemplate(runtime: $runtime, name: $name)
+		}`,
+		Variables: map[string]interface{}{
+			"runtime": runtime,
+			"name":    name,
+		},
+	}
+
+	return client.SendGqlRequest(&request, nil)
+}
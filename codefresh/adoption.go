@@ -0,0 +1,23 @@
+package codefresh
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// adoptExistingSchema the schema of the adopt_existing flag of the resources which can adopt
+// an existing entity with the same name instead of failing to create it
+func adoptExistingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+// getAdoptExisting returns whether the resource should adopt an existing entity on create,
+// the provider's adopt_existing is used unless adopt_existing is set on the resource
+func getAdoptExisting(d *schema.ResourceData, meta interface{}) bool {
+	if adopt, ok := d.GetOkExists("adopt_existing"); ok {
+		return adopt.(bool)
+	}
+	return getProviderSettings(meta).adoptExisting
+}
@@ -0,0 +1,92 @@
+package codefresh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// compatibilityFixturesDir holds a directory per released version of the provider, with the states
+// written by that version and the configurations they were created from
+const compatibilityFixturesDir = "../test_data/compatibility"
+
+type compatibilityFixture struct {
+	Resource   string                 `json:"resource"`
+	Config     map[string]interface{} `json:"config"`
+	Attributes map[string]string      `json:"attributes"`
+}
+
+// TestSchemaCompatibility checks that the states written by the previous versions of the provider
+// don't produce a plan with the current schema, so that upgrading the provider doesn't change the resources
+func TestSchemaCompatibility(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(compatibilityFixturesDir, "*", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No compatibility fixtures found in %s", compatibilityFixturesDir)
+	}
+
+	provider := Provider()
+	meta := cfClient.NewClient("", "", "", "")
+	for _, file := range files {
+		name, _ := filepath.Rel(compatibilityFixturesDir, file)
+		if err := checkCompatibilityFixture(provider.ResourcesMap, meta, file, t); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func checkCompatibilityFixture(resources map[string]*schema.Resource, meta interface{}, file string, t *testing.T) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var fixture compatibilityFixture
+	if err := json.Unmarshal(content, &fixture); err != nil {
+		return err
+	}
+
+	resource, ok := resources[fixture.Resource]
+	if !ok {
+		return fmt.Errorf("the resource %s doesn't exist anymore", fixture.Resource)
+	}
+
+	state := &terraform.InstanceState{ID: fixture.Attributes["id"], Attributes: fixture.Attributes}
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(fixture.Config), meta)
+	if err != nil {
+		return err
+	}
+	if diff.Empty() {
+		return nil
+	}
+
+	var errs []string
+	for key, attributeDiff := range diff.Attributes {
+		// the attributes added since the state was written are either read from the API on the refresh
+		// which precedes the plan, or set to their default on the first apply
+		if _, inState := state.Attributes[key]; !inState {
+			if attributeDiff.NewComputed {
+				continue
+			}
+			if attributeSchema := findAttributeSchema(resource.Schema, key); attributeSchema != nil && attributeSchema.Default != nil &&
+				fmt.Sprint(attributeSchema.Default) == attributeDiff.New {
+				t.Logf("%s: new attribute %s set to its default %s", filepath.Base(file), key, attributeDiff.New)
+				continue
+			}
+		}
+		errs = append(errs, fmt.Sprintf("%s: %q => %q (forces replacement: %v)", key, attributeDiff.Old, attributeDiff.New, attributeDiff.RequiresNew))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unexpected plan:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -1,14 +1,16 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceAccount() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceAccountRead,
+		ReadContext: dataSourceAccountRead,
 		Schema: map[string]*schema.Schema{
 			"_id": {
 				Type:     schema.TypeString,
@@ -25,13 +27,48 @@ func dataSourceAccount() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"limits": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"collaborators": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"collaborators_used": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"data_retention_weeks": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"features": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeBool,
+				},
+			},
+			"suspended": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func dataSourceAccountRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	var account *cfClient.Account
 	var err error
 
@@ -40,17 +77,17 @@ func dataSourceAccountRead(d *schema.ResourceData, meta interface{}) error {
 	} else if name, nameOk := d.GetOk("name"); nameOk {
 		account, err = client.GetAccountByName(name.(string))
 	} else {
-		return fmt.Errorf("data.codefresh_account - must specify _id or name")
+		return diag.Errorf("data.codefresh_account - must specify _id or name")
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if account == nil {
-		return fmt.Errorf("data.codefresh_account - cannot find account")
+		return diag.Errorf("data.codefresh_account - cannot find account")
 	}
 
-	return mapDataAccountToResource(account, d)
+	return diag.FromErr(mapDataAccountToResource(account, d))
 }
 
 func mapDataAccountToResource(account *cfClient.Account, d *schema.ResourceData) error {
@@ -63,6 +100,14 @@ func mapDataAccountToResource(account *cfClient.Account, d *schema.ResourceData)
 	d.Set("_id", account.ID)
 	d.Set("name", account.Name)
 	d.Set("admins", account.Admins)
+	d.Set("features", account.Features)
+	d.Set("created_at", account.CreatedAt)
+	d.Set("suspended", account.Suspension != nil && account.Suspension.IsSuspended)
+	if account.Limits != nil {
+		limits := flattenLimits(*account.Limits)
+		limits["collaborators_used"] = account.Limits.Collaborators.Used
+		d.Set("limits", []map[string]interface{}{limits})
+	}
 
 	return nil
 }
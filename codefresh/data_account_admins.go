@@ -0,0 +1,48 @@
+package codefresh
+
+import (
+	"context"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAccountAdmins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountAdminsRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountAdminsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	accountID := d.Get("account_id").(string)
+
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if account == nil {
+		return diag.Errorf("data.codefresh_account_admins - cannot find account %s", accountID)
+	}
+
+	d.SetId(account.ID)
+
+	return diag.FromErr(d.Set("users", account.Admins))
+}
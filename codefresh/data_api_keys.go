@@ -0,0 +1,86 @@
+package codefresh
+
+import (
+	"context"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApiKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceApiKeysRead,
+		Schema: map[string]*schema.Schema{
+			"api_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_used": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApiKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	apiKeys, err := client.GetApiKeysList()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mapDataApiKeysToResource(apiKeys, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
+
+// mapDataApiKeysToResource only the metadata of the keys is exposed, never the token
+func mapDataApiKeysToResource(apiKeys []cfClient.ApiKey, d *schema.ResourceData) error {
+
+	var res = make([]map[string]interface{}, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		m := make(map[string]interface{})
+		m["id"] = apiKey.ID
+		m["name"] = apiKey.Name
+		m["scopes"] = apiKey.Scopes
+		m["created"] = apiKey.Created
+		m["last_used"] = apiKey.LastUsed
+
+		res[i] = m
+	}
+
+	return d.Set("api_keys", res)
+}
@@ -0,0 +1,69 @@
+package codefresh
+
+import (
+	"context"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceClusters() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClustersRead,
+		Schema: map[string]*schema.Schema{
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"provider": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	clusters, err := client.GetClusters()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res := make([]map[string]interface{}, len(clusters))
+	for i, cluster := range clusters {
+		res[i] = map[string]interface{}{
+			"id":       cluster.ID,
+			"name":     cluster.Selector,
+			"provider": cluster.Provider,
+			"tags":     cluster.Tags,
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	return diag.FromErr(d.Set("clusters", res))
+}
@@ -1,21 +1,27 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/ghodss/yaml"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceContext() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceContextRead,
+		ReadContext: dataSourceContextRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"decrypt_spec": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"type": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -28,26 +34,26 @@ func dataSourceContext() *schema.Resource {
 	}
 }
 
-func dataSourceContextRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceContextRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	var context *cfClient.Context
 	var err error
 
 	if name, nameOk := d.GetOk("name"); nameOk {
-		context, err = client.GetContext(name.(string))
+		context, err = client.GetContextWithDecrypt(name.(string), getContextDecryptSpec(d, meta))
 	} else {
-		return fmt.Errorf("data.codefresh_context - must specify name")
+		return diag.Errorf("data.codefresh_context - must specify name")
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if context == nil {
-		return fmt.Errorf("data.codefresh_context - cannot find context")
+		return diag.Errorf("data.codefresh_context - cannot find context")
 	}
 
-	return mapDataContextToResource(context, d)
+	return diag.FromErr(mapDataContextToResource(context, d))
 }
 
 func mapDataContextToResource(context *cfClient.Context, d *schema.ResourceData) error {
@@ -0,0 +1,68 @@
+package codefresh
+
+import (
+	"context"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceContexts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceContextsRead,
+		Schema: map[string]*schema.Schema{
+			"types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"contexts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceContextsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	types := convertStringArr(d.Get("types").(*schema.Set).List())
+	contexts, err := client.GetContexts(&cfClient.ContextsFilter{Types: types})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res := make([]map[string]interface{}, 0, len(contexts))
+	for _, context := range contexts {
+		// the types are filtered again in case the API ignores the filter
+		if len(types) > 0 && !cfClient.FindInSlice(types, context.Spec.Type) {
+			continue
+		}
+		res = append(res, map[string]interface{}{
+			"name": context.Metadata.Name,
+			"type": context.Spec.Type,
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	return diag.FromErr(d.Set("contexts", res))
+}
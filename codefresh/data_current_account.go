@@ -1,14 +1,16 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceCurrentAccount() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCurrentAccountRead,
+		ReadContext: dataSourceCurrentAccountRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -42,21 +44,21 @@ func dataSourceCurrentAccount() *schema.Resource {
 	}
 }
 
-func dataSourceCurrentAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func dataSourceCurrentAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	var currentAccount *cfClient.CurrentAccount
 	var err error
 
 	currentAccount, err = client.GetCurrentAccount()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if currentAccount == nil {
-		return fmt.Errorf("data.codefresh_current_account - failed to get current_account")
+		return diag.Errorf("data.codefresh_current_account - failed to get current_account")
 	}
 
-	return mapDataCurrentAccountToResource(currentAccount, d)
+	return diag.FromErr(mapDataCurrentAccountToResource(currentAccount, d))
 
 }
 
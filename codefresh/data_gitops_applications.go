@@ -0,0 +1,115 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGitopsApplications() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGitopsApplicationsRead,
+		Schema: map[string]*schema.Schema{
+			"runtime": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cluster": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"health": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"HEALTHY",
+					"PROGRESSING",
+					"DEGRADED",
+					"SUSPENDED",
+					"MISSING",
+					"UNKNOWN",
+				}, false),
+			},
+			"applications": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"runtime": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sync_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGitopsApplicationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	filter := &cfClient.GitopsApplicationsFilter{
+		Runtime: d.Get("runtime").(string),
+		Cluster: d.Get("cluster").(string),
+		Health:  d.Get("health").(string),
+	}
+
+	applications, err := client.GetGitopsApplications(filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mapDataGitopsApplicationsToResource(applications, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the id reflects the filter, so that the data source is stable between refreshes
+	d.SetId(fmt.Sprintf("%s/%s/%s", filter.Runtime, filter.Cluster, filter.Health))
+
+	return nil
+}
+
+func mapDataGitopsApplicationsToResource(applications []cfClient.GitopsApplication, d *schema.ResourceData) error {
+
+	var res = make([]map[string]interface{}, len(applications))
+	for i, application := range applications {
+		m := make(map[string]interface{})
+		m["name"] = application.Metadata.Name
+		m["namespace"] = application.Metadata.Namespace
+		m["runtime"] = application.Metadata.Runtime
+		m["cluster"] = application.Metadata.Cluster
+		m["health_status"] = application.Status.HealthStatus
+		m["sync_status"] = application.Status.SyncStatus
+
+		res[i] = m
+	}
+
+	return d.Set("applications", res)
+}
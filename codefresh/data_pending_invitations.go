@@ -0,0 +1,110 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePendingInvitations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePendingInvitationsRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"older_than_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"invitations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"invited_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pending_days": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePendingInvitationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	users, err := client.GetAllUsers()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	accountID := d.Get("account_id").(string)
+	olderThanDays := d.Get("older_than_days").(int)
+
+	invitations := make([]map[string]interface{}, 0)
+	for _, user := range *users {
+		if user.Status != "pending" || (accountID != "" && !isUserInAccount(user, accountID)) {
+			continue
+		}
+
+		pendingDays := 0
+		if invitedAt, err := time.Parse(time.RFC3339, user.RegisterDate); err == nil {
+			pendingDays = int(time.Since(invitedAt).Hours() / 24)
+		} else {
+			log.Printf("[DEBUG] Unable to parse the register date of user %s. Error = %v", user.ID, err)
+		}
+		if pendingDays < olderThanDays {
+			continue
+		}
+
+		invitations = append(invitations, map[string]interface{}{
+			"user_id":      user.ID,
+			"user_name":    user.UserName,
+			"email":        user.Email,
+			"invited_at":   user.RegisterDate,
+			"pending_days": pendingDays,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", accountID, olderThanDays))
+
+	return diag.FromErr(d.Set("invitations", invitations))
+}
+
+func isUserInAccount(user cfClient.User, accountID string) bool {
+	for _, account := range user.Account {
+		if account.ID == accountID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePipeline() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePipelineRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_public": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"is_template": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"template_pipeline_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"canonical_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"canonical_json_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	pipeline, err := client.GetPipelineByName(d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if pipeline == nil {
+		return diag.Errorf("data.codefresh_pipeline - cannot find pipeline")
+	}
+
+	return diag.FromErr(mapDataPipelineToResource(pipeline, d))
+}
+
+func mapDataPipelineToResource(pipeline *cfClient.Pipeline, d *schema.ResourceData) error {
+
+	if pipeline == nil || pipeline.Metadata.ID == "" {
+		return fmt.Errorf("data.codefresh_pipeline - failed to mapDataPipelineToResource")
+	}
+	d.SetId(pipeline.Metadata.ID)
+
+	d.Set("name", pipeline.Metadata.Name)
+	d.Set("project_id", pipeline.Metadata.ProjectId)
+	d.Set("is_public", pipeline.Metadata.IsPublic)
+	d.Set("revision", pipeline.Metadata.Revision)
+	d.Set("tags", pipeline.Metadata.Labels.Tags)
+	if pipeline.Metadata.Template != nil {
+		d.Set("is_template", pipeline.Metadata.Template.IsTemplate)
+		d.Set("template_pipeline_id", pipeline.Metadata.Template.GeneratedFrom)
+	}
+
+	return setPipelineCanonicalJSON(*pipeline, d)
+}
@@ -0,0 +1,113 @@
+package codefresh
+
+import (
+	"context"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePipelinesByProject() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePipelinesByProjectRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_public": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"revision": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePipelinesByProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	var project *cfClient.Project
+	var err error
+	if projectID := d.Get("project_id").(string); projectID != "" {
+		project, err = client.GetProjectByID(projectID)
+	} else {
+		project, err = client.GetProjectByName(d.Get("project_name").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if project == nil || project.ID == "" {
+		return diag.Errorf("data.codefresh_pipelines_by_project - cannot find project")
+	}
+
+	pipelines, err := client.GetPipelines(&cfClient.PipelinesFilter{ProjectID: project.ID})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(project.ID)
+	d.Set("project_id", project.ID)
+	d.Set("project_name", project.ProjectName)
+
+	return diag.FromErr(d.Set("pipelines", flattenProjectPipelines(project.ID, pipelines)))
+}
+
+// flattenProjectPipelines flattens the pipelines of the project, the pipelines of other projects are skipped
+// in case the API ignores the filter
+func flattenProjectPipelines(projectID string, pipelines []cfClient.Pipeline) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		if pipeline.Metadata.ProjectId != projectID {
+			continue
+		}
+		res = append(res, map[string]interface{}{
+			"id":         pipeline.Metadata.ID,
+			"name":       pipeline.Metadata.Name,
+			"is_public":  pipeline.Metadata.IsPublic,
+			"revision":   pipeline.Metadata.Revision,
+			"tags":       pipeline.Metadata.Labels.Tags,
+			"updated_at": pipeline.Metadata.UpdatedAt,
+		})
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRuntime() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRuntimeRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"runtimes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ingress_host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"health_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sync_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRuntimeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	runtimes, err := client.GetGitopsRuntimes()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	if name != "" {
+		var matchingRuntimes []cfClient.GitopsRuntime
+		for _, runtime := range runtimes {
+			if runtime.Metadata.Name == name {
+				matchingRuntimes = append(matchingRuntimes, runtime)
+			}
+		}
+		if len(matchingRuntimes) == 0 {
+			return diag.Errorf("[ERROR] GitOps runtime %s wasn't found", name)
+		}
+		runtimes = matchingRuntimes
+	}
+
+	err = mapDataRuntimeToResource(runtimes, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("runtimes/%s", name))
+
+	return nil
+}
+
+func mapDataRuntimeToResource(runtimes []cfClient.GitopsRuntime, d *schema.ResourceData) error {
+
+	var res = make([]map[string]interface{}, len(runtimes))
+	for i, runtime := range runtimes {
+		m := make(map[string]interface{})
+		m["name"] = runtime.Metadata.Name
+		m["namespace"] = runtime.Metadata.Namespace
+		m["version"] = runtime.RuntimeVersion
+		m["cluster"] = runtime.Cluster
+		m["ingress_host"] = runtime.IngressHost
+		m["health_status"] = runtime.Status.HealthStatus
+		m["sync_status"] = runtime.Status.SyncStatus
+
+		res[i] = m
+	}
+
+	return d.Set("runtimes", res)
+}
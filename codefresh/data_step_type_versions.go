@@ -0,0 +1,127 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceStepTypeVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStepTypeVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version_constraint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateVersionConstraint,
+			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"released_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"latest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStepTypeVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	name := d.Get("name").(string)
+	versions, err := client.GetStepTypesVersions(name)
+	if err != nil {
+		return diag.Errorf("data.codefresh_step_type_versions - was unable to retrieve the versions for step_type %s: %v", name, err)
+	}
+
+	var constraint *semver.Constraints
+	if c, ok := d.GetOk("version_constraint"); ok {
+		constraint, err = semver.NewConstraint(c.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var res []map[string]interface{}
+	latest := ""
+	for _, version := range filterStepTypeVersions(versions, constraint) {
+		stepTypes, err := client.GetStepTypes(name + ":" + version)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		res = append(res, map[string]interface{}{
+			"version":     version,
+			"released_at": stepTypes.Metadata["created_at"],
+		})
+		latest = version
+	}
+
+	d.SetId(name)
+
+	err = d.Set("versions", res)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("latest", latest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterStepTypeVersions returns the versions matching the constraint ordered by semver, so that the result
+// doesn't depend on the order of publication. The versions which aren't valid semver can't be ordered and are skipped
+func filterStepTypeVersions(versions []string, constraint *semver.Constraints) []string {
+	var parsedVersions []*semver.Version
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			log.Printf("[DEBUG] Skipping version %s, it isn't a semver", version)
+			continue
+		}
+		if constraint == nil || constraint.Check(v) {
+			parsedVersions = append(parsedVersions, v)
+		}
+	}
+	sort.Sort(semver.Collection(parsedVersions))
+
+	res := make([]string, len(parsedVersions))
+	for i, v := range parsedVersions {
+		res[i] = v.Original()
+	}
+	return res
+}
+
+func validateVersionConstraint(i interface{}, k string) (warnings []string, errors []error) {
+	if _, err := semver.NewConstraint(i.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q isn't a valid version constraint: %v", k, err))
+	}
+	return warnings, errors
+}
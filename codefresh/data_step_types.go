@@ -1,16 +1,17 @@
 package codefresh
 
 import (
-	"fmt"
+	"context"
 	"log"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceStepTypes() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceStepTypesRead,
+		ReadContext: dataSourceStepTypesRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -36,9 +37,9 @@ func dataSourceStepTypes() *schema.Resource {
 	}
 }
 
-func dataSourceStepTypesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceStepTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	var err error
 	var versions []string
 	stepTypesIdentifier := d.Get("name").(string)
@@ -60,10 +61,10 @@ func dataSourceStepTypesRead(d *schema.ResourceData, meta interface{}) error {
 				stepVersions.Versions = append(stepVersions.Versions, stepVersion)
 			}
 		}
-		return mapStepTypesVersionsToResource(stepVersions, d)
+		return diag.FromErr(mapStepTypesVersionsToResource(stepVersions, d))
 	}
 
-	return fmt.Errorf("data.codefresh_step_types - was unable to retrieve the versions for step_type %s", stepTypesIdentifier)
+	return diag.Errorf("data.codefresh_step_types - was unable to retrieve the versions for step_type %s", stepTypesIdentifier)
 
 }
 
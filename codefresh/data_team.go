@@ -1,14 +1,16 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceTeam() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTeamRead,
+		ReadContext: dataSourceTeamRead,
 		Schema: map[string]*schema.Schema{
 			"_id": {
 				Type:     schema.TypeString,
@@ -44,9 +46,9 @@ func dataSourceTeam() *schema.Resource {
 	}
 }
 
-func dataSourceTeamRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	var team *cfClient.Team
 	var err error
 
@@ -58,14 +60,14 @@ func dataSourceTeamRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if team == nil {
-		return fmt.Errorf("data.codefresh_team - cannot find team")
+		return diag.Errorf("data.codefresh_team - cannot find team")
 	}
 
-	return mapDataTeamToResource(team, d)
+	return diag.FromErr(mapDataTeamToResource(team, d))
 
 }
 
@@ -0,0 +1,123 @@
+package codefresh
+
+import (
+	"context"
+	"sort"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTeamEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTeamEffectivePermissionsRead,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"permission_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTeamEffectivePermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	teamID := d.Get("team_id").(string)
+
+	permissions, err := client.GetPermissionList(teamID, "", "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("permissions", aggregateTeamPermissions(permissions))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(teamID)
+
+	return nil
+}
+
+// aggregateTeamPermissions merges the permissions of the team with the same resource and action,
+// the result is sorted by resource and action, so it can be compared with the expected policy
+func aggregateTeamPermissions(permissions []cfClient.Permission) []map[string]interface{} {
+	type key struct {
+		resource string
+		action   string
+	}
+	tags := map[key]map[string]bool{}
+	ids := map[key][]string{}
+	for _, permission := range permissions {
+		k := key{permission.Resource, permission.Action}
+		if _, ok := tags[k]; !ok {
+			tags[k] = map[string]bool{}
+		}
+		for _, tag := range permission.Tags {
+			tags[k][tag] = true
+		}
+		ids[k] = append(ids[k], permission.ID)
+	}
+
+	keys := make([]key, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].resource != keys[j].resource {
+			return keys[i].resource < keys[j].resource
+		}
+		return keys[i].action < keys[j].action
+	})
+
+	res := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		permissionTags := make([]string, 0, len(tags[k]))
+		for tag := range tags[k] {
+			permissionTags = append(permissionTags, tag)
+		}
+		sort.Strings(permissionTags)
+		sort.Strings(ids[k])
+
+		res[i] = map[string]interface{}{
+			"resource":       k.resource,
+			"action":         k.action,
+			"tags":           permissionTags,
+			"permission_ids": ids[k],
+		}
+	}
+	return res
+}
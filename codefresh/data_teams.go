@@ -0,0 +1,131 @@
+package codefresh
+
+import (
+	"context"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTeams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTeamsRead,
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"teams": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"users_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	teams, err := client.GetTeamList()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tags := convertStringArr(d.Get("tags").(*schema.Set).List())
+	err = mapDataTeamsToResource(filterTeamsByTags(teams, tags), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	return nil
+}
+
+// filterTeamsByTags returns the teams which have all the tags
+func filterTeamsByTags(teams []cfClient.Team, tags []string) []cfClient.Team {
+	if len(tags) == 0 {
+		return teams
+	}
+	var filtered []cfClient.Team
+	for _, team := range teams {
+		teamTags := make(map[string]bool, len(team.Tags))
+		for _, tag := range team.Tags {
+			teamTags[tag] = true
+		}
+		matches := true
+		for _, tag := range tags {
+			if !teamTags[tag] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, team)
+		}
+	}
+	return filtered
+}
+
+func mapDataTeamsToResource(teams []cfClient.Team, d *schema.ResourceData) error {
+
+	var res = make([]map[string]interface{}, len(teams))
+	for i, team := range teams {
+		var users []string
+		for _, user := range team.Users {
+			users = append(users, user.ID)
+		}
+
+		m := make(map[string]interface{})
+		m["id"] = team.ID
+		m["name"] = team.Name
+		m["type"] = team.Type
+		m["users"] = users
+		m["users_count"] = len(team.Users)
+		m["tags"] = team.Tags
+
+		res[i] = m
+	}
+
+	return d.Set("teams", res)
+}
@@ -1,26 +1,28 @@
 package codefresh
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceUser() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceUserRead,
-		Schema: *UserSchema(),
+		ReadContext: dataSourceUserRead,
+		Schema:      *UserSchema(),
 	}
 }
 
-func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	users, err := client.GetAllUsers()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	email := d.Get("email").(string)
@@ -29,13 +31,13 @@ func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
 		if user.Email == email {
 			err = mapDataUserToResource(user, d)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	if d.Id() == "" {
-		return errors.New(fmt.Sprintf("[EROOR] User %s wasn't found", email))
+		return diag.FromErr(errors.New(fmt.Sprintf("[EROOR] User %s wasn't found", email)))
 	}
 
 	return nil
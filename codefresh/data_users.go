@@ -1,14 +1,17 @@
 package codefresh
 
 import (
+	"context"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"time"
 )
 
 func dataSourceUsers() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceUsersRead,
+		ReadContext: dataSourceUsersRead,
 		Schema: map[string]*schema.Schema{
 			"users": {
 				Type:     schema.TypeList,
@@ -21,13 +24,13 @@ func dataSourceUsers() *schema.Resource {
 	}
 }
 
-func dataSourceUsersRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	users, err := client.GetAllUsers()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapDataUsersToResource(*users, d)
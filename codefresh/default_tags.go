@@ -0,0 +1,110 @@
+package codefresh
+
+import (
+	"fmt"
+	"sort"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultTagsSchema the schema of the provider's default_tags, the tags added to all the pipelines and projects
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandDefaultTags returns the default tags of the provider
+func expandDefaultTags(raw []interface{}) []string {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	return convertStringArr(raw[0].(map[string]interface{})["tags"].(*schema.Set).List())
+}
+
+// appliedDefaultTags returns the default tags of the provider which aren't excluded by the resource
+func appliedDefaultTags(meta interface{}, excluded []string) []string {
+	var applied []string
+	for _, tag := range getProviderSettings(meta).defaultTags {
+		if !cfClient.FindInSlice(excluded, tag) {
+			applied = append(applied, tag)
+		}
+	}
+	return applied
+}
+
+// mergeDefaultTags returns the sorted tags of the resource and the default tags applied to it
+func mergeDefaultTags(tags []string, defaultTags []string) []string {
+	merged := append([]string{}, tags...)
+	for _, tag := range defaultTags {
+		if !cfClient.FindInSlice(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// getTagsWithDefaults returns the tags sent to the API for the resource, including the default tags
+func getTagsWithDefaults(d *schema.ResourceData, meta interface{}) []string {
+	excluded := convertStringArr(d.Get("exclude_default_tags").(*schema.Set).List())
+	return mergeDefaultTags(convertStringArr(d.Get("tags").(*schema.Set).List()), appliedDefaultTags(meta, excluded))
+}
+
+// setTagsWithoutDefaults sets the tags read from the API in tags_all, and in tags without the default tags
+// which aren't set by the resource too, so the resources relying on the defaults don't show a diff
+func setTagsWithoutDefaults(entityTags []string, d *schema.ResourceData, meta interface{}) error {
+	err := d.Set("tags_all", entityTags)
+	if err != nil {
+		return err
+	}
+
+	configuredTags := convertStringArr(d.Get("tags").(*schema.Set).List())
+	excluded := convertStringArr(d.Get("exclude_default_tags").(*schema.Set).List())
+	defaultTags := appliedDefaultTags(meta, excluded)
+	tags := []string{}
+	for _, tag := range entityTags {
+		if cfClient.FindInSlice(defaultTags, tag) && !cfClient.FindInSlice(configuredTags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return d.Set("tags", tags)
+}
+
+// customizeTagsAll plans tags_all with the default tags, so that a change of the provider's default_tags
+// updates the pipelines and projects
+func customizeTagsAll(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") || !d.NewValueKnown("exclude_default_tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tags := convertStringArr(d.Get("tags").(*schema.Set).List())
+	excluded := convertStringArr(d.Get("exclude_default_tags").(*schema.Set).List())
+	expected := mergeDefaultTags(tags, appliedDefaultTags(meta, excluded))
+
+	oldTagsAll, _ := d.GetChange("tags_all")
+	current := mergeDefaultTags(convertStringArr(oldTagsAll.(*schema.Set).List()), nil)
+	if fmt.Sprint(current) == fmt.Sprint(expected) {
+		return nil
+	}
+	if d.Id() != "" && len(current) == 0 && !d.HasChange("tags") && fmt.Sprint(expected) == fmt.Sprint(mergeDefaultTags(tags, nil)) {
+		// the states written before tags_all existed, it's set by the next refresh
+		return nil
+	}
+	return d.SetNew("tags_all", expected)
+}
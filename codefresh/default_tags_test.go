@@ -0,0 +1,78 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDefaultTags(t *testing.T) {
+	meta := cfClient.NewClient("", "", "", "")
+	configuredProviderSettings.Store(meta, &providerSettings{defaultTags: []string{"managed-by-terraform", "team-platform"}})
+	defer configuredProviderSettings.Delete(meta)
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":                 "project",
+		"tags":                 []interface{}{"frontend"},
+		"exclude_default_tags": []interface{}{"team-platform"},
+	})
+	tags := getTagsWithDefaults(d, meta)
+	if expected := []string{"frontend", "managed-by-terraform"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected the tags %v to be sent, got %v", expected, tags)
+	}
+
+	// the default tags are hidden from tags unless the resource sets them too
+	err := setTagsWithoutDefaults([]string{"frontend", "managed-by-terraform", "team-platform"}, d, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readTags := convertStringArr(d.Get("tags").(*schema.Set).List())
+	sort.Strings(readTags)
+	if expected := []string{"frontend", "team-platform"}; !reflect.DeepEqual(readTags, expected) {
+		t.Errorf("Expected the tags %v to be read, got %v", expected, readTags)
+	}
+	if tagsAll := d.Get("tags_all").(*schema.Set).Len(); tagsAll != 3 {
+		t.Errorf("Expected the 3 tags of the project in tags_all, got %d", tagsAll)
+	}
+}
+
+func TestDefaultTagsDiff(t *testing.T) {
+	meta := cfClient.NewClient("", "", "", "")
+	defer configuredProviderSettings.Delete(meta)
+
+	setAttributes := func(attributes map[string]string, key string, values ...string) {
+		attributes[key+".#"] = fmt.Sprint(len(values))
+		for _, value := range values {
+			attributes[fmt.Sprintf("%s.%d", key, schema.HashString(value))] = value
+		}
+	}
+	attributes := map[string]string{"id": "project-id", "name": "project", "manage_variables_externally": "false"}
+	setAttributes(attributes, "tags", "frontend")
+	setAttributes(attributes, "tags_all", "frontend", "managed-by-terraform")
+	state := &terraform.InstanceState{ID: "project-id", Attributes: attributes}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "project", "tags": []interface{}{"frontend"}})
+
+	for _, c := range []struct {
+		defaultTags []string
+		changed     bool
+	}{
+		{[]string{"managed-by-terraform"}, false},
+		{[]string{"managed-by-terraform", "team-platform"}, true},
+		{nil, true},
+	} {
+		configuredProviderSettings.Store(meta, &providerSettings{defaultTags: c.defaultTags})
+		diff, err := resourceProject().Diff(context.Background(), state, config, meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed := diff != nil && !diff.Empty(); changed != c.changed {
+			t.Errorf("default tags %v: expected a diff %v, got %v", c.defaultTags, c.changed, diff)
+		}
+	}
+}
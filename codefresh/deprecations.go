@@ -0,0 +1,181 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deprecation describes an attribute scheduled for removal and how to migrate away from it.
+// The attribute is a path in the resource schema where * matches any index of a list, e.g. spec.0.trigger.*.context.
+// Only attributes which aren't computed are supported, as the value of a computed attribute
+// can't be told apart from a configured one at plan time.
+// With legacyValues, only the legacy values of the attribute are deprecated, not the attribute itself
+type deprecation struct {
+	attribute    string
+	legacyValues renamedValues
+	migration    string
+}
+
+func (dep deprecation) message() string {
+	if dep.legacyValues != nil {
+		return fmt.Sprintf("the legacy values of %s are deprecated and will be removed in a future version. %s", dep.attribute, dep.migration)
+	}
+	return fmt.Sprintf("%s is deprecated and will be removed in a future version. %s", dep.attribute, dep.migration)
+}
+
+// attributeGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type attributeGetter interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}
+
+// withDeprecations marks the attributes of the resource as deprecated, so that Terraform shows the migration
+// instructions as warnings at plan time. When the provider is configured with strict_deprecations
+// the plan fails instead, to catch the usage of deprecated attributes in CI
+func withDeprecations(resource *schema.Resource, deprecations ...deprecation) *schema.Resource {
+
+	for _, dep := range deprecations {
+		attributeSchema := findAttributeSchema(resource.Schema, dep.attribute)
+		if attributeSchema == nil {
+			panic(fmt.Sprintf("withDeprecations - cannot find attribute %s", dep.attribute))
+		}
+		// the legacy values are warned about by the validation of the attribute
+		if dep.legacyValues == nil {
+			attributeSchema.Deprecated = dep.message()
+		}
+	}
+
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if getProviderSettings(meta).strictDeprecations {
+			if errs := findDeprecatedAttributes(d, deprecations); len(errs) > 0 {
+				return fmt.Errorf("strict_deprecations is enabled:\n%s", strings.Join(errs, "\n"))
+			}
+		}
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+
+	return resource
+}
+
+// renamedValues maps the legacy values of an attribute, renamed by the platform, to their current values.
+// The legacy values are migrated transparently: they are sent with their current value, don't cause diffs
+// against the value read from the API, and show a warning at plan time
+type renamedValues map[string]string
+
+func (values renamedValues) migrate(value string) string {
+	if current, ok := values[value]; ok {
+		return current
+	}
+	return value
+}
+
+func (values renamedValues) validate(val interface{}, key string) (warns []string, errs []error) {
+	if current, ok := values[val.(string)]; ok {
+		warns = append(warns, fmt.Sprintf("%q: %q was renamed %q by Codefresh and is migrated automatically, use %q instead", key, val, current, current))
+	}
+	return
+}
+
+func (values renamedValues) suppressDiff(k, old, new string, d *schema.ResourceData) bool {
+	return values.migrate(old) == values.migrate(new)
+}
+
+// renamedResourceType keeps a renamed resource type working under its legacy name, so that the existing
+// configurations don't break when the provider is upgraded. The legacy type shares the implementation and
+// the IDs of the current one, the resources are migrated with a removed block and an import block
+type renamedResourceType struct {
+	legacyName  string
+	currentName string
+}
+
+// legacyResource returns a copy of the resource of the current type which shows a deprecation warning
+// with the migration instructions
+func (renamed renamedResourceType) legacyResource(resource *schema.Resource) *schema.Resource {
+	legacy := *resource
+	legacy.DeprecationMessage = fmt.Sprintf("%s was renamed %s and will be removed in a future version. "+
+		"Rename the resources in the configuration and migrate their state with a removed block for %s "+
+		"and an import block for %s with the same ID, or with terraform state rm and terraform import",
+		renamed.legacyName, renamed.currentName, renamed.legacyName, renamed.currentName)
+	return &legacy
+}
+
+// withRenamedResourceTypes registers the legacy names of the renamed resource types
+func withRenamedResourceTypes(resources map[string]*schema.Resource, renamedTypes ...renamedResourceType) map[string]*schema.Resource {
+	for _, renamed := range renamedTypes {
+		resource, ok := resources[renamed.currentName]
+		if !ok {
+			panic(fmt.Sprintf("withRenamedResourceTypes - cannot find resource type %s", renamed.currentName))
+		}
+		resources[renamed.legacyName] = renamed.legacyResource(resource)
+	}
+	return resources
+}
+
+// findDeprecatedAttributes returns the messages of the deprecated attributes that are set,
+// or set to a legacy value
+func findDeprecatedAttributes(d attributeGetter, deprecations []deprecation) []string {
+	var res []string
+	for _, dep := range deprecations {
+		for _, attribute := range expandAttributePath(d, dep.attribute) {
+			value, ok := d.GetOk(attribute)
+			if !ok {
+				continue
+			}
+			if dep.legacyValues != nil {
+				if _, legacy := dep.legacyValues[fmt.Sprint(value)]; !legacy {
+					continue
+				}
+				res = append(res, fmt.Sprintf("%s = %q: %s", attribute, value, dep.message()))
+				continue
+			}
+			res = append(res, fmt.Sprintf("%s: %s", attribute, dep.message()))
+		}
+	}
+	return res
+}
+
+// expandAttributePath replaces the * in the path with each index of the list, e.g. spec.0.trigger.*.name
+// returns spec.0.trigger.0.name and spec.0.trigger.1.name for a pipeline with 2 triggers
+func expandAttributePath(d attributeGetter, path string) []string {
+	index := strings.Index(path, "*")
+	if index == -1 {
+		return []string{path}
+	}
+
+	var res []string
+	prefix := strings.TrimSuffix(path[:index], ".")
+	if list, ok := d.Get(prefix).([]interface{}); ok {
+		for i := range list {
+			res = append(res, expandAttributePath(d, path[:index]+strconv.Itoa(i)+path[index+1:])...)
+		}
+	}
+	return res
+}
+
+func findAttributeSchema(s map[string]*schema.Schema, path string) *schema.Schema {
+	var res *schema.Schema
+	for _, part := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(part); err == nil || part == "*" {
+			continue
+		}
+		if res != nil {
+			resource, ok := res.Elem.(*schema.Resource)
+			if !ok {
+				return nil
+			}
+			s = resource.Schema
+		}
+		if res = s[part]; res == nil {
+			return nil
+		}
+	}
+	return res
+}
@@ -0,0 +1,118 @@
+package codefresh
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Unit Testing
+func TestFindDeprecatedAttributes(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePipeline().Schema, map[string]interface{}{
+		"name": "project/pipeline",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"trigger": []interface{}{
+					map[string]interface{}{"name": "first"},
+					map[string]interface{}{"name": "second", "commit_status_title": "title"},
+				},
+			},
+		},
+	})
+
+	deprecations := []deprecation{
+		{attribute: "spec.0.trigger.*.commit_status_title", migration: "Use the name instead."},
+		{attribute: "is_public", migration: "Nothing to do."},
+	}
+
+	expected := []string{"spec.0.trigger.1.commit_status_title: " + deprecations[0].message()}
+	if found := findDeprecatedAttributes(d, deprecations); !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+}
+
+func TestFindDeprecatedLegacyValues(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePipelineTrigger().Schema, map[string]interface{}{
+		"pipeline_id":  "5f1fd9044d0fc94ddf9c5ce1",
+		"name":         "commits",
+		"git_provider": "stash",
+	})
+
+	deprecations := []deprecation{{attribute: "git_provider", legacyValues: renamedTriggerProviders, migration: renamedTriggerProvidersMigration}}
+	expected := []string{`git_provider = "stash": ` + deprecations[0].message()}
+	if found := findDeprecatedAttributes(d, deprecations); !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+
+	if err := d.Set("git_provider", "bitbucket-server"); err != nil {
+		t.Fatal(err)
+	}
+	if found := findDeprecatedAttributes(d, deprecations); len(found) != 0 {
+		t.Errorf("Expected the current value not to be deprecated, got %v", found)
+	}
+	if resourcePipelineTrigger().Schema["git_provider"].Deprecated != "" {
+		t.Errorf("Expected the attribute with legacy values not to be deprecated")
+	}
+}
+
+func TestWithDeprecations(t *testing.T) {
+	resource := withDeprecations(resourcePipeline(), deprecation{
+		attribute: "spec.0.trigger.*.commit_status_title",
+		migration: "Use the name instead.",
+	})
+
+	attributeSchema := findAttributeSchema(resource.Schema, "spec.0.trigger.*.commit_status_title")
+	if attributeSchema == nil || attributeSchema.Deprecated == "" {
+		t.Errorf("Attribute wasn't marked as deprecated")
+	}
+
+	if findAttributeSchema(resource.Schema, "spec.0.trigger.*.unknown") != nil {
+		t.Errorf("Unknown attribute was found")
+	}
+}
+
+func TestRenamedValues(t *testing.T) {
+	values := renamedValues{"stash": "bitbucket-server"}
+
+	if migrated := values.migrate("stash"); migrated != "bitbucket-server" {
+		t.Errorf("Expected stash to be migrated to bitbucket-server, got %s", migrated)
+	}
+	if migrated := values.migrate("github"); migrated != "github" {
+		t.Errorf("Expected github to be kept, got %s", migrated)
+	}
+
+	if warns, errs := values.validate("stash", "provider"); len(warns) != 1 || len(errs) != 0 {
+		t.Errorf("Expected a warning for the legacy value, got %v %v", warns, errs)
+	}
+	if warns, _ := values.validate("bitbucket-server", "provider"); len(warns) != 0 {
+		t.Errorf("Expected no warning for the current value, got %v", warns)
+	}
+
+	if !values.suppressDiff("provider", "bitbucket-server", "stash", nil) {
+		t.Errorf("Expected no diff between the legacy value and the current one")
+	}
+	if values.suppressDiff("provider", "bitbucket-server", "github", nil) {
+		t.Errorf("Expected a diff between different providers")
+	}
+}
+
+func TestRenamedResourceTypes(t *testing.T) {
+	resources := withRenamedResourceTypes(map[string]*schema.Resource{
+		"codefresh_abac_rule": resourcePermission(),
+	}, renamedResourceType{legacyName: "codefresh_permission", currentName: "codefresh_abac_rule"})
+
+	legacy, current := resources["codefresh_permission"], resources["codefresh_abac_rule"]
+	if legacy == nil {
+		t.Fatal("Expected the legacy resource type to be registered")
+	}
+	if legacy.DeprecationMessage == "" || current.DeprecationMessage != "" {
+		t.Errorf("Expected only the legacy resource type to be deprecated")
+	}
+	if !reflect.DeepEqual(legacy.Schema, current.Schema) {
+		t.Errorf("Expected the legacy resource type to share the schema of the current one")
+	}
+	if err := legacy.InternalValidate(nil, true); err != nil {
+		t.Errorf("Unexpected invalid legacy resource type: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package codefresh
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// entityIDRegexp matches the ids of the entities of the API, which are MongoDB object ids
+var entityIDRegexp = regexp.MustCompile("^[0-9a-f]{24}$")
+
+func isEntityID(id string) bool {
+	return entityIDRegexp.MatchString(id)
+}
+
+// importPipelineState imports a pipeline by id or by name, project/name or the bare name of a pipeline outside
+// of the projects
+func importPipelineState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(cfClient.CodefreshAPI)
+
+	importID := strings.TrimSpace(d.Id())
+	if importID == "" {
+		return nil, fmt.Errorf("unable to import the pipeline, expected the pipeline id or its name")
+	}
+	// a name may look like an id, it's looked up by name when no pipeline has this id
+	if isEntityID(importID) {
+		_, err := client.GetPipeline(importID)
+		if err == nil {
+			d.SetId(importID)
+			return []*schema.ResourceData{d}, nil
+		}
+		if !cfClient.IsNotFoundError(err) {
+			return nil, err
+		}
+	}
+
+	pipeline, err := client.GetPipelineByName(importID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to import the pipeline %s, no pipeline has this id or name: %v", importID, err)
+	}
+	d.SetId(pipeline.Metadata.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importContextState imports a context by name, the context id isn't used by the API
+func importContextState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(cfClient.CodefreshAPI)
+
+	importID := strings.TrimSpace(d.Id())
+	if importID == "" {
+		return nil, fmt.Errorf("unable to import the context, expected the context name")
+	}
+	_, err := client.GetContextWithDecrypt(importID, false)
+	if cfClient.IsNotFoundError(err) {
+		return nil, fmt.Errorf("unable to import the context %s, contexts are imported by name and no context has this name", importID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(importID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importPermissionState imports a permission by id, the permissions have no name
+func importPermissionState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	importID := strings.TrimSpace(d.Id())
+	if !isEntityID(importID) {
+		return nil, fmt.Errorf("unable to import the permission %q, expected the permission id, e.g. from the codefresh_team_effective_permissions data source", importID)
+	}
+	d.SetId(importID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importTeamState imports a team by id or by name
+func importTeamState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(cfClient.CodefreshAPI)
+
+	importID := strings.TrimSpace(d.Id())
+	if isEntityID(importID) {
+		d.SetId(importID)
+		return []*schema.ResourceData{d}, nil
+	}
+	if importID == "" {
+		return nil, fmt.Errorf("unable to import the team, expected the team id or name")
+	}
+
+	team, err := client.GetTeamByName(importID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("unable to import the team %s, no team has this name or id", importID)
+	}
+	d.SetId(team.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,84 @@
+package codefresh
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importAPI mocks the lookups of the importers
+type importAPI struct {
+	cfClient.CodefreshAPI
+}
+
+func (api *importAPI) GetPipeline(id string) (*cfClient.Pipeline, error) {
+	if id == "5f1fda324d0fc90e739c5ce4" {
+		return &cfClient.Pipeline{Metadata: cfClient.Metadata{ID: id}}, nil
+	}
+	return nil, &cfClient.APIError{StatusCode: http.StatusNotFound}
+}
+
+func (api *importAPI) GetPipelineByName(name string) (*cfClient.Pipeline, error) {
+	switch name {
+	case "project/build":
+		return &cfClient.Pipeline{Metadata: cfClient.Metadata{ID: "5f1fda324d0fc90e739c5ce4", Name: name}}, nil
+	case "build", "deadbeefdeadbeefdeadbeef":
+		return &cfClient.Pipeline{Metadata: cfClient.Metadata{ID: "5f1fdb1e4d0fc97c2a9c5ce5", Name: name}}, nil
+	}
+	return nil, fmt.Errorf("GetPipelineByName - cannot find pipeline by name %s", name)
+}
+
+func (api *importAPI) GetContextWithDecrypt(name string, decrypt bool) (*cfClient.Context, error) {
+	if name == "shared" {
+		return &cfClient.Context{}, nil
+	}
+	return nil, &cfClient.APIError{StatusCode: http.StatusNotFound}
+}
+
+func (api *importAPI) GetTeamByName(name string) (*cfClient.Team, error) {
+	if name == "developers" {
+		return &cfClient.Team{ID: "5f1fd9a54d0fc9b1d39c5ce2", Name: name}, nil
+	}
+	return nil, nil
+}
+
+func TestImporters(t *testing.T) {
+	cases := []struct {
+		name        string
+		resource    *schema.Resource
+		importID    string
+		expectedID  string
+		expectError bool
+	}{
+		{"pipeline by id", resourcePipeline(), "5f1fda324d0fc90e739c5ce4", "5f1fda324d0fc90e739c5ce4", false},
+		{"pipeline by full name", resourcePipeline(), "project/build", "5f1fda324d0fc90e739c5ce4", false},
+		{"pipeline by unknown full name", resourcePipeline(), "project/unknown", "", true},
+		{"pipeline by bare name", resourcePipeline(), "build", "5f1fdb1e4d0fc97c2a9c5ce5", false},
+		{"pipeline by a name which looks like an id", resourcePipeline(), "deadbeefdeadbeefdeadbeef", "5f1fdb1e4d0fc97c2a9c5ce5", false},
+		{"pipeline by unknown bare name", resourcePipeline(), "unknown", "", true},
+		{"context by name", resourceContext(), "shared", "shared", false},
+		{"unknown context", resourceContext(), "unknown", "", true},
+		{"permission by id", resourcePermission(), "5f1fd9dc4d0fc9a3a59c5ce3", "5f1fd9dc4d0fc9a3a59c5ce3", false},
+		{"permission by something else", resourcePermission(), "developers/pipeline/run", "", true},
+		{"team by id", resourceTeam(), "5f1fd9a54d0fc9b1d39c5ce2", "5f1fd9a54d0fc9b1d39c5ce2", false},
+		{"team by name", resourceTeam(), "developers", "5f1fd9a54d0fc9b1d39c5ce2", false},
+		{"unknown team", resourceTeam(), "unknown", "", true},
+	}
+
+	for _, c := range cases {
+		d := c.resource.TestResourceData()
+		d.SetId(c.importID)
+
+		res, err := c.resource.Importer.State(d, &importAPI{})
+		if (err != nil) != c.expectError {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+			continue
+		}
+		if err == nil && res[0].Id() != c.expectedID {
+			t.Errorf("%s: expected ID %s, got %s", c.name, c.expectedID, res[0].Id())
+		}
+	}
+}
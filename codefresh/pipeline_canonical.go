@@ -0,0 +1,72 @@
+package codefresh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pipelineDefinitionKeys the attributes of the resource which change the definition of the pipeline
+var pipelineDefinitionKeys = []string{"name", "project_id", "tags", "tags_all", "is_public", "is_template", "original_yaml_string", "spec"}
+
+// pipelineCanonicalJSON returns the definition of the pipeline as JSON with sorted keys and its SHA-256,
+// so that the supply-chain tooling can sign and attest the exact pipeline deployed. The fields changed
+// by the platform on every save, i.e. the ID, the revision and the update time, are left out
+func pipelineCanonicalJSON(pipeline cfClient.Pipeline) (string, string, error) {
+	pipeline.Metadata.ID = ""
+	pipeline.Metadata.Revision = 0
+	pipeline.Metadata.UpdatedAt = ""
+
+	encoded, err := json.Marshal(pipeline)
+	if err != nil {
+		return "", "", err
+	}
+	// the maps are encoded with sorted keys unlike the structs
+	var definition interface{}
+	err = json.Unmarshal(encoded, &definition)
+	if err != nil {
+		return "", "", err
+	}
+	canonical, err := json.Marshal(definition)
+	if err != nil {
+		return "", "", err
+	}
+
+	checksum := sha256.Sum256(canonical)
+	return string(canonical), hex.EncodeToString(checksum[:]), nil
+}
+
+// setPipelineCanonicalJSON sets the canonical_json and canonical_json_sha256 attributes of the pipeline
+func setPipelineCanonicalJSON(pipeline cfClient.Pipeline, d *schema.ResourceData) error {
+	canonical, checksum, err := pipelineCanonicalJSON(pipeline)
+	if err != nil {
+		return err
+	}
+	err = d.Set("canonical_json", canonical)
+	if err != nil {
+		return err
+	}
+	return d.Set("canonical_json_sha256", checksum)
+}
+
+// customizePipelineCanonicalJSON plans the canonical JSON as unknown when the definition of the pipeline changes
+func customizePipelineCanonicalJSON(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+	changed := false
+	for _, key := range pipelineDefinitionKeys {
+		changed = changed || d.HasChange(key)
+	}
+	if !changed {
+		return nil
+	}
+	err := d.SetNewComputed("canonical_json")
+	if err != nil {
+		return err
+	}
+	return d.SetNewComputed("canonical_json_sha256")
+}
@@ -0,0 +1,69 @@
+package codefresh
+
+import (
+	"log"
+	"sync"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type parallelBuildsEntry struct {
+	once     sync.Once
+	parallel int
+	err      error
+}
+
+// accountParallelBuilds the parallel builds of the account are loaded once per provider configuration,
+// as they're needed by each pipeline
+var accountParallelBuilds sync.Map
+
+func getAccountParallelBuilds(client cfClient.CodefreshAPI) (int, error) {
+	value, _ := accountParallelBuilds.LoadOrStore(client, &parallelBuildsEntry{})
+	entry := value.(*parallelBuildsEntry)
+	entry.once.Do(func() {
+		entry.parallel, entry.err = client.GetCurrentAccountParallelBuilds()
+	})
+	return entry.parallel, entry.err
+}
+
+// effectiveConcurrency the number of builds of the pipeline which can actually run at the same time,
+// a zero concurrency is unlimited and an unknown account limit doesn't restrict it
+func effectiveConcurrency(concurrency int, parallelBuilds int) int {
+	if parallelBuilds <= 0 {
+		return concurrency
+	}
+	if concurrency == 0 || concurrency > parallelBuilds {
+		return parallelBuilds
+	}
+	return concurrency
+}
+
+// getEffectiveConcurrency returns the effective concurrency of the pipeline, the concurrency requested by the pipeline
+// when the limit of the account can't be read, e.g. the token isn't allowed to
+func getEffectiveConcurrency(meta interface{}, concurrency int) int {
+	client, ok := meta.(cfClient.CodefreshAPI)
+	if !ok {
+		return concurrency
+	}
+	parallelBuilds, err := getAccountParallelBuilds(client)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to get the parallel builds of the account. Error = %v", err)
+		return concurrency
+	}
+	return effectiveConcurrency(concurrency, parallelBuilds)
+}
+
+// customizeEffectiveConcurrency plans the effective concurrency of the pipeline and warns when the pipeline
+// requests more concurrent builds than the account allows
+func customizeEffectiveConcurrency(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("spec.0.concurrency") {
+		return nil
+	}
+	concurrency := d.Get("spec.0.concurrency").(int)
+	effective := getEffectiveConcurrency(meta, concurrency)
+	if concurrency > effective {
+		log.Printf("[WARN] The pipeline %s requests a concurrency of %d but the account only allows %d parallel builds", d.Get("name"), concurrency, effective)
+	}
+	return d.SetNew("effective_concurrency", effective)
+}
@@ -0,0 +1,280 @@
+package codefresh
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// the timezones of the cron triggers are resolved on the systems without a timezone database too, e.g. Windows
+	_ "time/tzdata"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maxCronJitterMinutes the largest jitter window of the cron triggers, a day
+const maxCronJitterMinutes = 24*60 - 1
+
+// pipelineCronTriggerSchema the schema of the cron_trigger blocks of the pipeline spec
+func pipelineCronTriggerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateTriggerName,
+		},
+		"expression": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"message": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"disabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"git_trigger_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"branch": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"variables": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"timezone": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "UTC",
+			Description: "The timezone of the expression. The API has no timezone, the expression is moved to UTC " +
+				"with the offset of the timezone at the time of the apply: it's a fixed offset until the next apply",
+			ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
+				if _, err := time.LoadLocation(i.(string)); err != nil {
+					errors = append(errors, fmt.Errorf("%q: %s isn't a timezone of the IANA database, e.g. Europe/Paris", k, i))
+				}
+				return
+			},
+		},
+		"jitter_minutes": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, maxCronJitterMinutes),
+		},
+	}
+}
+
+// cronJitter returns the delay in minutes of the trigger within the jitter window, derived from the seed
+// so that it doesn't change between the plans
+func cronJitter(seed string, jitterMinutes int) int {
+	if jitterMinutes <= 0 {
+		return 0
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(seed))
+	return int(hash.Sum32() % uint32(jitterMinutes+1))
+}
+
+// cronExpressionInUTC returns the expression evaluated by the API in UTC for the expression in the timezone, delayed by
+// the jitter of the trigger. The offset of the timezone at the time now is used: the API keeps running the expression
+// at this fixed offset when the daylight saving time starts or ends, until the next apply moves it again. Only the
+// expressions with a fixed minute and a fixed list of hours can be moved
+func cronExpressionInUTC(expression string, timezone string, jitterMinutes int, seed string, now time.Time) (string, error) {
+	if (timezone == "" || timezone == "UTC") && jitterMinutes == 0 {
+		return expression, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(expression)
+	unsupported := fmt.Errorf("the expression %q can't be moved to the timezone or delayed by the jitter, "+
+		"expected 5 fields with a fixed minute and hours, e.g. \"30 2,14 * * *\"", expression)
+	if len(fields) != 5 {
+		return "", unsupported
+	}
+	minute, err := strconv.Atoi(fields[0])
+	if err != nil || minute < 0 || minute > 59 {
+		return "", unsupported
+	}
+
+	_, offsetSeconds := now.In(location).Zone()
+	shift := cronJitter(seed, jitterMinutes) - offsetSeconds/60
+
+	var hours []int
+	movedMinute := 0
+	movedDay := false
+	for _, field := range strings.Split(fields[1], ",") {
+		hour, err := strconv.Atoi(field)
+		if err != nil || hour < 0 || hour > 23 {
+			return "", unsupported
+		}
+		total := hour*60 + minute + shift
+		if total < 0 || total >= 24*60 {
+			movedDay = true
+			total = (total%(24*60) + 24*60) % (24 * 60)
+		}
+		// the shift is the same for all the hours, so is the minute
+		movedMinute = total % 60
+		hours = append(hours, total/60)
+	}
+	if movedDay && (fields[2] != "*" || fields[3] != "*" || fields[4] != "*") {
+		return "", fmt.Errorf("the expression %q runs on another day in UTC, the day of month, month and day of week "+
+			"must be * to use the timezone or the jitter, or write the expression in UTC", expression)
+	}
+
+	sort.Ints(hours)
+	hourFields := make([]string, len(hours))
+	for i, hour := range hours {
+		hourFields[i] = strconv.Itoa(hour)
+	}
+	return fmt.Sprintf("%d %s %s %s %s", movedMinute, strings.Join(hourFields, ","), fields[2], fields[3], fields[4]), nil
+}
+
+// cronTriggerSeed the seed of the jitter of the trigger, unique in the account
+func cronTriggerSeed(pipelineName string, triggerName string) string {
+	return pipelineName + "/" + triggerName
+}
+
+// mapResourceToCronTriggers returns the cron triggers of the pipeline with their expression in UTC
+func mapResourceToCronTriggers(pipelineName string, rawCronTriggers []interface{}, now time.Time) ([]cfClient.CronTrigger, error) {
+	var cronTriggers []cfClient.CronTrigger
+	for _, raw := range rawCronTriggers {
+		cronTrigger := raw.(map[string]interface{})
+		name := cronTrigger["name"].(string)
+		expression, err := cronExpressionInUTC(cronTrigger["expression"].(string), cronTrigger["timezone"].(string),
+			cronTrigger["jitter_minutes"].(int), cronTriggerSeed(pipelineName, name), now)
+		if err != nil {
+			return nil, fmt.Errorf("cron trigger %s: %v", name, err)
+		}
+
+		var variables []cfClient.Variable
+		for key, value := range cronTrigger["variables"].(map[string]interface{}) {
+			variables = append(variables, cfClient.Variable{Key: key, Value: value.(string)})
+		}
+		cronTriggers = append(cronTriggers, cfClient.CronTrigger{
+			Name:         name,
+			Type:         "cron",
+			Expression:   expression,
+			Message:      cronTrigger["message"].(string),
+			Disabled:     cronTrigger["disabled"].(bool),
+			GitTriggerID: cronTrigger["git_trigger_id"].(string),
+			Branch:       cronTrigger["branch"].(string),
+			Variables:    variables,
+		})
+	}
+	return cronTriggers, nil
+}
+
+// fixedOffsetTimezones returns the Etc timezones with the other offsets of the timezone during the year, e.g.
+// Etc/GMT-1 for the winter offset of Europe/Paris in summer. The offsets which aren't whole hours have no Etc timezone
+func fixedOffsetTimezones(timezone string, now time.Time) []string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil
+	}
+	_, currentOffset := now.In(location).Zone()
+	var timezones []string
+	for months := 1; months < 12; months++ {
+		_, offset := now.AddDate(0, months, 0).In(location).Zone()
+		if offset == currentOffset || offset%3600 != 0 {
+			continue
+		}
+		// the sign of the Etc timezones is inverted, Etc/GMT-1 is UTC+1
+		fixed := "UTC"
+		if offset != 0 {
+			fixed = fmt.Sprintf("Etc/GMT%+d", -offset/3600)
+		}
+		if !cfClient.FindInSlice(timezones, fixed) {
+			timezones = append(timezones, fixed)
+		}
+	}
+	return timezones
+}
+
+// flattenCronTriggers flattens the cron triggers read from the API. The timezone and the jitter aren't stored by
+// the API, they are kept from the state with the expression when the expression in UTC is still the one computed
+// from them. When the offset of the timezone changed since the apply, e.g. the daylight saving time started, the
+// timezone is read as the fixed offset still used by the API, so the plan shows the change of the timezone and the
+// next apply moves the expression again. Otherwise the expression in UTC shows a diff
+func flattenCronTriggers(pipelineName string, cronTriggers []cfClient.CronTrigger, stateCronTriggers []interface{}, now time.Time) []map[string]interface{} {
+	stateByName := map[string]map[string]interface{}{}
+	for _, raw := range stateCronTriggers {
+		if stateCronTrigger, ok := raw.(map[string]interface{}); ok {
+			stateByName[stateCronTrigger["name"].(string)] = stateCronTrigger
+		}
+	}
+
+	var res []map[string]interface{}
+	for _, cronTrigger := range cronTriggers {
+		m := map[string]interface{}{
+			"name":           cronTrigger.Name,
+			"expression":     cronTrigger.Expression,
+			"message":        cronTrigger.Message,
+			"disabled":       cronTrigger.Disabled,
+			"git_trigger_id": cronTrigger.GitTriggerID,
+			"branch":         cronTrigger.Branch,
+			"variables":      convertVariables(cronTrigger.Variables),
+			"timezone":       "UTC",
+			"jitter_minutes": 0,
+		}
+		if stateCronTrigger, ok := stateByName[cronTrigger.Name]; ok {
+			m["timezone"] = stateCronTrigger["timezone"]
+			m["jitter_minutes"] = stateCronTrigger["jitter_minutes"]
+			stateExpression := stateCronTrigger["expression"].(string)
+			expression, err := cronExpressionInUTC(stateExpression, stateCronTrigger["timezone"].(string),
+				stateCronTrigger["jitter_minutes"].(int), cronTriggerSeed(pipelineName, cronTrigger.Name), now)
+			if err == nil && expression == cronTrigger.Expression {
+				m["expression"] = stateExpression
+			} else {
+				for _, fixed := range fixedOffsetTimezones(stateCronTrigger["timezone"].(string), now) {
+					expression, err := cronExpressionInUTC(stateExpression, fixed,
+						stateCronTrigger["jitter_minutes"].(int), cronTriggerSeed(pipelineName, cronTrigger.Name), now)
+					if err == nil && expression == cronTrigger.Expression {
+						m["expression"] = stateExpression
+						m["timezone"] = fixed
+						break
+					}
+				}
+			}
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// validateCronTriggers checks at plan time that the expressions of the cron triggers can be moved to UTC,
+// the triggers with values unknown at plan time are checked on apply
+func validateCronTriggers(pipelineName string, rawCronTriggers []interface{}) error {
+	for i, raw := range rawCronTriggers {
+		cronTrigger, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		expression, _ := cronTrigger["expression"].(string)
+		timezone, _ := cronTrigger["timezone"].(string)
+		jitterMinutes, _ := cronTrigger["jitter_minutes"].(int)
+		name, _ := cronTrigger["name"].(string)
+		if expression == "" {
+			continue
+		}
+		_, err := cronExpressionInUTC(expression, timezone, jitterMinutes, cronTriggerSeed(pipelineName, name), time.Now())
+		if err != nil {
+			return fmt.Errorf("spec.0.cron_trigger.%d: %v", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package codefresh
+
+import (
+	"testing"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+)
+
+func TestCronExpressionInUTC(t *testing.T) {
+	winter := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, c := range []struct {
+		expression string
+		timezone   string
+		now        time.Time
+		expected   string
+	}{
+		{"*/5 * * * *", "UTC", winter, "*/5 * * * *"},
+		{"30 2 * * *", "Europe/Paris", winter, "30 1 * * *"},
+		{"30 2 * * *", "Europe/Paris", summer, "30 0 * * *"},
+		{"0 1,13 * * *", "Europe/Paris", summer, "0 11,23 * * *"},
+		{"15 8 * * 1-5", "Asia/Kolkata", winter, "45 2 * * 1-5"},
+		{"0 20 * * *", "America/New_York", winter, "0 1 * * *"},
+	} {
+		expression, err := cronExpressionInUTC(c.expression, c.timezone, 0, "project/pipeline/nightly", c.now)
+		if err != nil {
+			t.Errorf("%s in %s: %v", c.expression, c.timezone, err)
+			continue
+		}
+		if expression != c.expected {
+			t.Errorf("%s in %s: expected %s, got %s", c.expression, c.timezone, c.expected, expression)
+		}
+	}
+
+	for _, c := range []struct {
+		expression string
+		timezone   string
+	}{
+		{"*/5 * * * *", "Europe/Paris"},
+		{"0 1-5 * * *", "Europe/Paris"},
+		{"0 0 * * * *", "Europe/Paris"},
+		// the day changes in UTC
+		{"0 0 * * 1", "Europe/Paris"},
+	} {
+		if _, err := cronExpressionInUTC(c.expression, c.timezone, 0, "project/pipeline/nightly", winter); err == nil {
+			t.Errorf("%s in %s: expected an error", c.expression, c.timezone)
+		}
+	}
+}
+
+func TestCronJitter(t *testing.T) {
+	spread := map[int]bool{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seed := cronTriggerSeed("project/pipeline-"+name, "nightly")
+		jitter := cronJitter(seed, 30)
+		if jitter < 0 || jitter > 30 {
+			t.Errorf("Expected a jitter within 30 minutes, got %d", jitter)
+		}
+		if jitter != cronJitter(seed, 30) {
+			t.Errorf("Expected the jitter of %s to be stable", seed)
+		}
+		spread[jitter] = true
+	}
+	if len(spread) < 2 {
+		t.Errorf("Expected the triggers to be spread over the window, got %v", spread)
+	}
+}
+
+func TestFlattenCronTriggers(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	stateCronTriggers := []interface{}{
+		map[string]interface{}{"name": "nightly", "expression": "30 2 * * *", "timezone": "Europe/Paris", "jitter_minutes": 0},
+	}
+
+	cronTriggers, err := mapResourceToCronTriggers("project/pipeline", []interface{}{
+		map[string]interface{}{"name": "nightly", "expression": "30 2 * * *", "timezone": "Europe/Paris", "jitter_minutes": 0,
+			"message": "", "disabled": false, "git_trigger_id": "", "branch": "", "variables": map[string]interface{}{"MODE": "full"}},
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cronTriggers[0].Expression != "30 1 * * *" || cronTriggers[0].Type != "cron" {
+		t.Errorf("Expected the expression in UTC to be sent, got %+v", cronTriggers[0])
+	}
+
+	// the expression of the configuration is kept while it matches the expression in UTC
+	flattened := flattenCronTriggers("project/pipeline", cronTriggers, stateCronTriggers, now)
+	if flattened[0]["expression"] != "30 2 * * *" || flattened[0]["timezone"] != "Europe/Paris" {
+		t.Errorf("Expected the configured expression and timezone to be kept, got %v", flattened[0])
+	}
+
+	// after the start of the daylight saving time, the fixed offset still used by the API shows a diff of the timezone
+	summer := time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)
+	flattened = flattenCronTriggers("project/pipeline", cronTriggers, stateCronTriggers, summer)
+	if flattened[0]["expression"] != "30 2 * * *" || flattened[0]["timezone"] != "Etc/GMT-1" {
+		t.Errorf("Expected the configured expression with the fixed offset of the apply, got %v", flattened[0])
+	}
+
+	// the expression changed in the UI shows a diff
+	changed := []cfClient.CronTrigger{{Name: "nightly", Expression: "0 3 * * *"}}
+	flattened = flattenCronTriggers("project/pipeline", changed, stateCronTriggers, now)
+	if flattened[0]["expression"] != "0 3 * * *" {
+		t.Errorf("Expected the expression in UTC to be read, got %v", flattened[0])
+	}
+}
@@ -0,0 +1,210 @@
+package codefresh
+
+import (
+	"reflect"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// pipelineSpecDefaultsSchema the schema of the provider's pipeline_spec_defaults, a subset of the pipeline spec
+func pipelineSpecDefaultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"priority": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"concurrency": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"branch_concurrency": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"trigger_concurrency": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"runtime_environment": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"memory": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"cpu": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"dind_storage": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"termination_policy": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"on_create_branch": {
+								Type:     schema.TypeList,
+								Optional: true,
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"branch_name": {
+											Type:         schema.TypeString,
+											Optional:     true,
+											ValidateFunc: stringIsValidRe2RegExp,
+										},
+										"ignore_trigger": {
+											Type:     schema.TypeBool,
+											Optional: true,
+										},
+										"ignore_branch": {
+											Type:     schema.TypeBool,
+											Optional: true,
+										},
+									},
+								},
+							},
+							"on_terminate_annotation": {
+								Type:     schema.TypeBool,
+								Optional: true,
+								Default:  false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandPipelineSpecDefaults returns the spec holding the defaults of the provider, nil when they aren't set
+func expandPipelineSpecDefaults(raw []interface{}) *cfClient.Spec {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	defaults := raw[0].(map[string]interface{})
+
+	spec := &cfClient.Spec{
+		Priority:           defaults["priority"].(int),
+		Concurrency:        defaults["concurrency"].(int),
+		BranchConcurrency:  defaults["branch_concurrency"].(int),
+		TriggerConcurrency: defaults["trigger_concurrency"].(int),
+	}
+
+	if runtimeEnvironments := defaults["runtime_environment"].([]interface{}); len(runtimeEnvironments) > 0 && runtimeEnvironments[0] != nil {
+		runtimeEnvironment := runtimeEnvironments[0].(map[string]interface{})
+		spec.RuntimeEnvironment = cfClient.RuntimeEnvironment{
+			Name:        runtimeEnvironment["name"].(string),
+			Memory:      runtimeEnvironment["memory"].(string),
+			CPU:         runtimeEnvironment["cpu"].(string),
+			DindStorage: runtimeEnvironment["dind_storage"].(string),
+		}
+	}
+
+	if terminationPolicies := defaults["termination_policy"].([]interface{}); len(terminationPolicies) > 0 && terminationPolicies[0] != nil {
+		terminationPolicy := terminationPolicies[0].(map[string]interface{})
+		if onCreateBranches := terminationPolicy["on_create_branch"].([]interface{}); len(onCreateBranches) > 0 {
+			// the attributes with zero values are omitted, as for the pipelines
+			onCreateBranchPolicy := getSupportedTerminationPolicyAttributes("on_create_branch")
+			if onCreateBranch, ok := onCreateBranches[0].(map[string]interface{}); ok {
+				if branchName := onCreateBranch["branch_name"].(string); branchName != "" {
+					onCreateBranchPolicy["branchName"] = branchName
+				}
+				if onCreateBranch["ignore_trigger"].(bool) {
+					onCreateBranchPolicy["ignoreTrigger"] = true
+				}
+				if onCreateBranch["ignore_branch"].(bool) {
+					onCreateBranchPolicy["ignoreBranch"] = true
+				}
+			}
+			spec.TerminationPolicy = append(spec.TerminationPolicy, onCreateBranchPolicy)
+		}
+		if terminationPolicy["on_terminate_annotation"].(bool) {
+			onTerminateAnnotationPolicy := getSupportedTerminationPolicyAttributes("on_terminate_annotation")
+			onTerminateAnnotationPolicy["key"] = "cf_predecessor"
+			spec.TerminationPolicy = append(spec.TerminationPolicy, onTerminateAnnotationPolicy)
+		}
+	}
+
+	return spec
+}
+
+// applyPipelineSpecDefaults sets the defaults of the provider in the attributes of the spec which aren't set by the pipeline
+func applyPipelineSpecDefaults(spec *cfClient.Spec, defaults *cfClient.Spec) {
+	if defaults == nil {
+		return
+	}
+	if spec.Priority == 0 {
+		spec.Priority = defaults.Priority
+	}
+	if spec.Concurrency == 0 {
+		spec.Concurrency = defaults.Concurrency
+	}
+	if spec.BranchConcurrency == 0 {
+		spec.BranchConcurrency = defaults.BranchConcurrency
+	}
+	if spec.TriggerConcurrency == 0 {
+		spec.TriggerConcurrency = defaults.TriggerConcurrency
+	}
+	if spec.RuntimeEnvironment == (cfClient.RuntimeEnvironment{}) {
+		spec.RuntimeEnvironment = defaults.RuntimeEnvironment
+	}
+	if len(spec.TerminationPolicy) == 0 {
+		spec.TerminationPolicy = defaults.TerminationPolicy
+	}
+}
+
+// removePipelineSpecDefaults removes the defaults of the provider from the spec read from the API, when the attributes
+// aren't set in the state, so the pipelines relying on the defaults don't show a diff
+func removePipelineSpecDefaults(spec *cfClient.Spec, defaults *cfClient.Spec, d *schema.ResourceData) {
+	if defaults == nil {
+		return
+	}
+	if d.Get("spec.0.priority").(int) == 0 && spec.Priority == defaults.Priority {
+		spec.Priority = 0
+	}
+	if d.Get("spec.0.concurrency").(int) == 0 && spec.Concurrency == defaults.Concurrency {
+		spec.Concurrency = 0
+	}
+	if d.Get("spec.0.branch_concurrency").(int) == 0 && spec.BranchConcurrency == defaults.BranchConcurrency {
+		spec.BranchConcurrency = 0
+	}
+	if d.Get("spec.0.trigger_concurrency").(int) == 0 && spec.TriggerConcurrency == defaults.TriggerConcurrency {
+		spec.TriggerConcurrency = 0
+	}
+	if len(d.Get("spec.0.runtime_environment").([]interface{})) == 0 && spec.RuntimeEnvironment == defaults.RuntimeEnvironment {
+		spec.RuntimeEnvironment = cfClient.RuntimeEnvironment{}
+	}
+	if len(d.Get("spec.0.termination_policy").([]interface{})) == 0 && len(defaults.TerminationPolicy) > 0 &&
+		reflect.DeepEqual(flattenSpecTerminationPolicy(spec.TerminationPolicy), flattenSpecTerminationPolicy(defaults.TerminationPolicy)) {
+		spec.TerminationPolicy = nil
+	}
+}
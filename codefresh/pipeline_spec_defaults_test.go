@@ -0,0 +1,63 @@
+package codefresh
+
+import (
+	"reflect"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPipelineSpecDefaults(t *testing.T) {
+	defaults := expandPipelineSpecDefaults([]interface{}{
+		map[string]interface{}{
+			"priority":            0,
+			"concurrency":         0,
+			"branch_concurrency":  1,
+			"trigger_concurrency": 0,
+			"runtime_environment": []interface{}{
+				map[string]interface{}{"name": "system/default", "memory": "", "cpu": "", "dind_storage": ""},
+			},
+			"termination_policy": []interface{}{
+				map[string]interface{}{"on_create_branch": []interface{}{}, "on_terminate_annotation": true},
+			},
+		},
+	})
+
+	// the pipeline sets its own runtime environment
+	d := schema.TestResourceDataRaw(t, resourcePipeline().Schema, map[string]interface{}{
+		"name": "project/pipeline",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"runtime_environment": []interface{}{
+					map[string]interface{}{"name": "team/runtime"},
+				},
+			},
+		},
+	})
+
+	pipeline := mapResourceToPipeline(d)
+	applyPipelineSpecDefaults(&pipeline.Spec, defaults)
+
+	if pipeline.Spec.BranchConcurrency != 1 {
+		t.Errorf("Expected the default branch concurrency 1, got %d", pipeline.Spec.BranchConcurrency)
+	}
+	if pipeline.Spec.RuntimeEnvironment.Name != "team/runtime" {
+		t.Errorf("Expected the runtime environment of the pipeline, got %s", pipeline.Spec.RuntimeEnvironment.Name)
+	}
+	if !reflect.DeepEqual(pipeline.Spec.TerminationPolicy, defaults.TerminationPolicy) {
+		t.Errorf("Expected the default termination policy, got %v", pipeline.Spec.TerminationPolicy)
+	}
+
+	// the defaults read from the API are removed, so they don't show a diff
+	removePipelineSpecDefaults(&pipeline.Spec, defaults, d)
+
+	expected := cfClient.Spec{
+		RuntimeEnvironment: cfClient.RuntimeEnvironment{Name: "team/runtime"},
+	}
+	if pipeline.Spec.BranchConcurrency != expected.BranchConcurrency ||
+		pipeline.Spec.RuntimeEnvironment != expected.RuntimeEnvironment ||
+		pipeline.Spec.TerminationPolicy != nil {
+		t.Errorf("Expected the defaults to be removed, got %+v", pipeline.Spec)
+	}
+}
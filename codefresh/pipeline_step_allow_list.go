@@ -0,0 +1,199 @@
+package codefresh
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultStepType the type of the steps without a type
+const defaultStepType = "freestyle"
+
+// pipelineHooks the hooks of the pipelines and of the steps, which run steps too
+var pipelineHooks = []string{"on_elected", "on_finish", "on_success", "on_fail"}
+
+// pipelineStepAllowList the step types and images allowed in the inline YAML of the pipelines,
+// as patterns matched with path.Match. An empty list allows everything
+type pipelineStepAllowList struct {
+	stepTypes []string
+	images    []string
+}
+
+// pipelineStepAllowListSchema the schema of the provider's pipeline_step_allow_list
+func pipelineStepAllowListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"step_types": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"images": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandPipelineStepAllowList returns the allow-list of the provider, nil when it isn't set
+func expandPipelineStepAllowList(raw []interface{}) *pipelineStepAllowList {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	allowList := raw[0].(map[string]interface{})
+	return &pipelineStepAllowList{
+		stepTypes: convertStringArr(allowList["step_types"].(*schema.Set).List()),
+		images:    convertStringArr(allowList["images"].(*schema.Set).List()),
+	}
+}
+
+// matchesAnyPattern whether the value matches one of the patterns, or there are no patterns
+func matchesAnyPattern(value string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// mapSliceValue returns the value of the key of the YAML map, nil when it isn't set
+func mapSliceValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if itemKey, ok := item.Key.(string); ok && itemKey == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// lintPipelineSteps returns the violations of the allow-list by the steps of the inline YAML of a pipeline,
+// including the steps of the parallel steps and of the hooks
+func lintPipelineSteps(originalYamlString string, allowList *pipelineStepAllowList) ([]string, error) {
+	pipelineYaml := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(originalYamlString), &pipelineYaml); err != nil {
+		return nil, fmt.Errorf("original_yaml_string isn't valid YAML: %v", err)
+	}
+	return lintPipelineDefinition(pipelineYaml, allowList), nil
+}
+
+// lintPipelineStepsJSON returns the violations of the allow-list by the steps of the steps_json of a pipeline,
+// which are parsed as YAML to keep their order
+func lintPipelineStepsJSON(stepsJSON string, allowList *pipelineStepAllowList) ([]string, error) {
+	normalized, err := normalizeStepsJSON(stepsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("steps_json isn't valid JSON: %v", err)
+	}
+	steps := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(normalized), &steps); err != nil {
+		return nil, fmt.Errorf("steps_json isn't valid JSON: %v", err)
+	}
+	return lintPipelineDefinition(yaml.MapSlice{{Key: "steps", Value: steps}}, allowList), nil
+}
+
+// lintPipelineDefinition returns the violations of the allow-list by the steps and the hooks of a pipeline
+func lintPipelineDefinition(pipelineYaml yaml.MapSlice, allowList *pipelineStepAllowList) []string {
+	var violations []string
+	var lintSteps func(parent string, steps interface{})
+	var lintHooks func(parent string, hooks interface{})
+
+	lintStep := func(name string, step yaml.MapSlice) {
+		stepType, _ := mapSliceValue(step, "type").(string)
+		if stepType == "" {
+			stepType = defaultStepType
+		}
+		// the parallel steps only group other steps, which are checked
+		if stepType != "parallel" && !matchesAnyPattern(stepType, allowList.stepTypes) {
+			violations = append(violations, fmt.Sprintf("step %s: the step type %s isn't allowed", name, stepType))
+		}
+		if image, ok := mapSliceValue(step, "image").(string); ok && stepType == defaultStepType && !matchesAnyPattern(image, allowList.images) {
+			violations = append(violations, fmt.Sprintf("step %s: the image %s isn't allowed", name, image))
+		}
+		lintSteps(name+".", mapSliceValue(step, "steps"))
+		lintHooks(name+".", mapSliceValue(step, "hooks"))
+	}
+
+	lintSteps = func(parent string, steps interface{}) {
+		stepsMap, ok := steps.(yaml.MapSlice)
+		if !ok {
+			return
+		}
+		for _, item := range stepsMap {
+			if step, ok := item.Value.(yaml.MapSlice); ok {
+				lintStep(fmt.Sprintf("%s%v", parent, item.Key), step)
+			}
+		}
+	}
+
+	lintHooks = func(parent string, hooks interface{}) {
+		hooksMap, ok := hooks.(yaml.MapSlice)
+		if !ok {
+			return
+		}
+		for _, item := range hooksMap {
+			hookName, _ := item.Key.(string)
+			hook, ok := item.Value.(yaml.MapSlice)
+			if !ok || !cfClient.FindInSlice(pipelineHooks, hookName) {
+				continue
+			}
+			// a hook runs either a single exec step or steps
+			if exec, ok := mapSliceValue(hook, "exec").(yaml.MapSlice); ok {
+				lintStep(parent+"hooks."+hookName, exec)
+			}
+			lintSteps(parent+"hooks."+hookName+".", mapSliceValue(hook, "steps"))
+		}
+	}
+
+	lintSteps("", mapSliceValue(pipelineYaml, "steps"))
+	lintHooks("", mapSliceValue(pipelineYaml, "hooks"))
+	return violations
+}
+
+// customizePipelineStepAllowList fails the plan of the pipelines whose inline YAML or steps_json use the step
+// types or images not allowed by the provider's pipeline_step_allow_list
+func customizePipelineStepAllowList(d *schema.ResourceDiff, meta interface{}) error {
+	allowList := getProviderSettings(meta).pipelineStepAllowList
+	if allowList == nil {
+		return nil
+	}
+
+	var violations []string
+	if originalYamlString := d.Get("original_yaml_string").(string); originalYamlString != "" && d.NewValueKnown("original_yaml_string") {
+		yamlViolations, err := lintPipelineSteps(originalYamlString, allowList)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, yamlViolations...)
+	}
+	if stepsJSON := d.Get("spec.0.steps_json").(string); stepsJSON != "" && d.NewValueKnown("spec.0.steps_json") {
+		jsonViolations, err := lintPipelineStepsJSON(stepsJSON, allowList)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, jsonViolations...)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("the pipeline %s doesn't comply with the provider's pipeline_step_allow_list:\n  %s",
+			d.Get("name").(string), strings.Join(violations, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package codefresh
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLintPipelineSteps(t *testing.T) {
+	allowList := &pipelineStepAllowList{
+		stepTypes: []string{"freestyle", "build", "codefresh/*"},
+		images:    []string{"alpine:*", "registry.example.com/*"},
+	}
+
+	originalYamlString := `
+version: "1.0"
+steps:
+  clone:
+    type: git-clone
+    repo: org/repo
+  test:
+    image: alpine:3.18
+    commands:
+      - make test
+  checks:
+    type: parallel
+    steps:
+      lint:
+        image: golangci/golangci-lint:latest
+      scan:
+        type: codefresh/scanner
+  build:
+    type: build
+    image_name: org/app
+hooks:
+  on_fail:
+    exec:
+      image: curlimages/curl
+  on_success:
+    steps:
+      notify:
+        type: slack-notifier
+        image: registry.example.com/slack
+`
+	violations, err := lintPipelineSteps(originalYamlString, allowList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"step clone: the step type git-clone isn't allowed",
+		"step checks.lint: the image golangci/golangci-lint:latest isn't allowed",
+		"step hooks.on_fail: the image curlimages/curl isn't allowed",
+		"step hooks.on_success.notify: the step type slack-notifier isn't allowed",
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Expected the violations %v, got %v", expected, violations)
+	}
+
+	// an empty list allows everything
+	violations, err = lintPipelineSteps(originalYamlString, &pipelineStepAllowList{})
+	if err != nil || len(violations) > 0 {
+		t.Errorf("Expected no violation without patterns, got %v %v", violations, err)
+	}
+
+	if _, err := lintPipelineSteps("steps: [", allowList); err == nil {
+		t.Error("Expected an error for an invalid YAML")
+	}
+}
+
+func TestLintPipelineStepsJSON(t *testing.T) {
+	allowList := &pipelineStepAllowList{
+		stepTypes: []string{"freestyle", "build"},
+		images:    []string{"alpine:*"},
+	}
+
+	stepsJSON := `[
+		{"clone": {"type": "git-clone", "repo": "org/repo"}},
+		{"test": {"image": "alpine:3.18", "commands": ["make test"]}},
+		{"checks": {"type": "parallel", "steps": {"lint": {"image": "golangci/golangci-lint:latest"}}}},
+		{"build": {"type": "build", "image_name": "org/app"}}
+	]`
+	violations, err := lintPipelineStepsJSON(stepsJSON, allowList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"step clone: the step type git-clone isn't allowed",
+		"step checks.lint: the image golangci/golangci-lint:latest isn't allowed",
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Expected the violations %v, got %v", expected, violations)
+	}
+
+	if _, err := lintPipelineStepsJSON(`{"clone":`, allowList); err == nil {
+		t.Error("Expected an error for an invalid JSON")
+	}
+}
+
+func TestPipelineStepAllowListDiff(t *testing.T) {
+	meta := cfClient.NewClient("", "", "", "")
+	defer configuredProviderSettings.Delete(meta)
+	configuredProviderSettings.Store(meta, &providerSettings{pipelineStepAllowList: &pipelineStepAllowList{stepTypes: []string{"freestyle"}}})
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "project/pipeline",
+		"spec": []interface{}{
+			map[string]interface{}{"steps_json": `{"clone": {"type": "git-clone", "repo": "org/repo"}}`},
+		},
+	})
+	_, err := resourcePipeline().Diff(context.Background(), nil, config, meta)
+	if err == nil || !strings.Contains(err.Error(), "step clone: the step type git-clone isn't allowed") {
+		t.Errorf("Expected the plan of the steps_json to fail, got %v", err)
+	}
+}
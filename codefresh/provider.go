@@ -1,13 +1,47 @@
 package codefresh
 
 import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	//"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"os"
 )
 
+// defaultAPIRateLimit keeps the large applies under the rate limits of the API, the requests of
+// a few hundred resources are spread over a few seconds instead of being throttled
+const defaultAPIRateLimit = 10.0
+
+// providerSettings holds the provider configuration which is not related to the API client
+type providerSettings struct {
+	strictDeprecations     bool
+	defaultDecryptContexts bool
+	adoptExisting          bool
+	validateTagsExist      string
+	pipelineSpecDefaults   *cfClient.Spec
+	pipelineStepAllowList  *pipelineStepAllowList
+	defaultTags            []string
+}
+
+// configuredProviderSettings stores the settings of each configured provider by its meta,
+// so that they are not shared between provider aliases
+var configuredProviderSettings sync.Map
+
+// getProviderSettings returns the settings of the provider which configured the meta
+func getProviderSettings(meta interface{}) *providerSettings {
+	if settings, ok := configuredProviderSettings.Load(meta); ok {
+		return settings.(*providerSettings)
+	}
+	return &providerSettings{defaultDecryptContexts: true}
+}
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -21,34 +55,197 @@ func Provider() *schema.Provider {
 					return "https://g.codefresh.io/api", nil
 				},
 			},
+			"api_url_v2": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: func() (interface{}, error) {
+					if url := os.Getenv("CODEFRESH_API2_URL"); url != "" {
+						return url, nil
+					}
+					return "https://g.codefresh.io/2.0", nil
+				},
+			},
 			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"token_file", "token_command"},
+			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"token", "token_command"},
+			},
+			"token_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"token", "token_file"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"expected_account_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expected_account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// a string, the booleans of the configurations written when it was a boolean are converted by terraform
+			"validate_tags_exist": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "false",
+				ValidateFunc: validation.StringInSlice([]string{"false", "true", validateTagsExistWarn, validateTagsExistError}, false),
+			},
+			"strict_deprecations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_decrypt_contexts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"gzip_requests": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"pipeline_spec_defaults":   pipelineSpecDefaultsSchema(),
+			"pipeline_step_allow_list": pipelineStepAllowListSchema(),
+			"default_tags":             defaultTagsSchema(),
+			"additional_headers": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_base_delay_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      500,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"proxy_url": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"http_proxy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"https_proxy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"no_proxy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_tls_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"api_rate_limit": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				DefaultFunc: func() (interface{}, error) {
+					if rate := os.Getenv("CODEFRESH_API_RATE_LIMIT"); rate != "" {
+						return strconv.ParseFloat(rate, 64)
+					}
+					return defaultAPIRateLimit, nil
+				},
+				ValidateFunc:  validation.FloatAtLeast(0),
+				ConflictsWith: []string{"requests_per_second"},
+			},
+			"requests_per_second": {
+				Type:          schema.TypeFloat,
+				Optional:      true,
+				Deprecated:    "requests_per_second was renamed api_rate_limit and will be removed in a future version. Use api_rate_limit instead.",
+				ValidateFunc:  validation.FloatAtLeast(0),
+				ConflictsWith: []string{"api_rate_limit"},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"codefresh_account":         dataSourceAccount(),
-			"codefresh_context":         dataSourceContext(),
-			"codefresh_current_account": dataSourceCurrentAccount(),
-			"codefresh_idps":            dataSourceIdps(),
-			"codefresh_step_types":      dataSourceStepTypes(),
-			"codefresh_team":            dataSourceTeam(),
-			"codefresh_user":            dataSourceUser(),
-			"codefresh_users":           dataSourceUsers(),
-		},
-		ResourcesMap: map[string]*schema.Resource{
-			"codefresh_account":        resourceAccount(),
-			"codefresh_account_admins": resourceAccountAdmins(),
-			"codefresh_api_key":        resourceApiKey(),
-			"codefresh_context":        resourceContext(),
-			"codefresh_idp_accounts":   resourceIDPAccounts(),
-			"codefresh_permission":     resourcePermission(),
-			"codefresh_pipeline":       resourcePipeline(),
-			"codefresh_project":        resourceProject(),
-			"codefresh_step_types":     resourceStepTypes(),
-			"codefresh_user":           resourceUser(),
-			"codefresh_team":           resourceTeam(),
+			"codefresh_account":                    dataSourceAccount(),
+			"codefresh_account_admins":             dataSourceAccountAdmins(),
+			"codefresh_api_keys":                   dataSourceApiKeys(),
+			"codefresh_clusters":                   dataSourceClusters(),
+			"codefresh_context":                    dataSourceContext(),
+			"codefresh_contexts":                   dataSourceContexts(),
+			"codefresh_current_account":            dataSourceCurrentAccount(),
+			"codefresh_gitops_applications":        dataSourceGitopsApplications(),
+			"codefresh_idps":                       dataSourceIdps(),
+			"codefresh_pending_invitations":        dataSourcePendingInvitations(),
+			"codefresh_pipeline":                   dataSourcePipeline(),
+			"codefresh_pipelines_by_project":       dataSourcePipelinesByProject(),
+			"codefresh_runtime":                    dataSourceRuntime(),
+			"codefresh_step_type_versions":         dataSourceStepTypeVersions(),
+			"codefresh_step_types":                 dataSourceStepTypes(),
+			"codefresh_team":                       dataSourceTeam(),
+			"codefresh_team_effective_permissions": dataSourceTeamEffectivePermissions(),
+			"codefresh_teams":                      dataSourceTeams(),
+			"codefresh_user":                       dataSourceUser(),
+			"codefresh_users":                      dataSourceUsers(),
 		},
+		// the renamed resource types are listed after the map, e.g.
+		// renamedResourceType{legacyName: "codefresh_permission", currentName: "codefresh_abac_rule"}
+		ResourcesMap: withRenamedResourceTypes(map[string]*schema.Resource{
+			"codefresh_account":                    resourceAccount(),
+			"codefresh_account_admins":             resourceAccountAdmins(),
+			"codefresh_account_runtime_allocation": resourceAccountRuntimeAllocation(),
+			"codefresh_account_security":           resourceAccountSecurity(),
+			"codefresh_account_sso_enforcement":    resourceAccountSSOEnforcement(),
+			"codefresh_api_key":                    resourceApiKey(),
+			"codefresh_context":                    resourceContext(),
+			"codefresh_execution_context":          resourceExecutionContext(),
+			"codefresh_generic_entity":             resourceGenericEntity(),
+			"codefresh_gitops_cluster":             resourceGitopsCluster(),
+			"codefresh_idp_accounts":               resourceIDPAccounts(),
+			"codefresh_permission":                 resourcePermission(),
+			"codefresh_pipeline":                   resourcePipeline(),
+			"codefresh_pipeline_trigger":           resourcePipelineTrigger(),
+			"codefresh_project":                    resourceProject(),
+			"codefresh_project_permissions":        resourceProjectPermissions(),
+			"codefresh_project_variables":          resourceProjectVariables(),
+			"codefresh_step_types":                 resourceStepTypes(),
+			"codefresh_user":                       resourceUser(),
+			"codefresh_team":                       resourceTeam(),
+			"codefresh_team_project_role":          resourceTeamProjectRole(),
+			"codefresh_workflow_template":          resourceWorkflowTemplate(),
+		}),
 		ConfigureFunc: configureProvider,
 	}
 }
@@ -56,9 +253,92 @@ func Provider() *schema.Provider {
 func configureProvider(d *schema.ResourceData) (interface{}, error) {
 
 	apiURL := d.Get("api_url").(string)
-	token := d.Get("token").(string)
-	if token == "" {
-		token = os.Getenv("CODEFRESH_API_KEY")
+	apiURLV2 := d.Get("api_url_v2").(string)
+	token, err := getProviderToken(d)
+	if err != nil {
+		return nil, err
+	}
+	// requests_per_second is the legacy name of api_rate_limit, 0 is unlimited so it's looked up even when it's 0
+	rateLimit := d.Get("api_rate_limit").(float64)
+	if requestsPerSecond, ok := d.GetOkExists("requests_per_second"); ok {
+		rateLimit = requestsPerSecond.(float64)
+	}
+	options := []cfClient.Option{
+		cfClient.WithAPIToken(token),
+		cfClient.WithGitopsHost(apiURLV2),
+		cfClient.WithRateLimit(rateLimit),
+		cfClient.WithRetryPolicy(d.Get("max_retries").(int), time.Duration(d.Get("retry_base_delay_ms").(int))*time.Millisecond),
+	}
+	if d.Get("gzip_requests").(bool) {
+		options = append(options, cfClient.WithGzipRequests())
+	}
+	if d.Get("read_only").(bool) {
+		options = append(options, cfClient.WithReadOnly())
+	}
+	additionalHeaders := map[string]string{}
+	for name, value := range d.Get("additional_headers").(map[string]interface{}) {
+		additionalHeaders[name] = value.(string)
+	}
+	options = append(options, cfClient.WithAdditionalHeaders(additionalHeaders))
+
+	// proxy_url is the proxy of both schemes unless the proxy of the scheme is set
+	httpProxy, httpsProxy := d.Get("http_proxy").(string), d.Get("https_proxy").(string)
+	if httpProxy == "" {
+		httpProxy = d.Get("proxy_url").(string)
+	}
+	if httpsProxy == "" {
+		httpsProxy = d.Get("proxy_url").(string)
+	}
+	options = append(options, cfClient.WithProxy(httpProxy, httpsProxy, d.Get("no_proxy").(string)))
+
+	caCertPEM := d.Get("ca_cert_pem").(string)
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		caCertPEM, err = cfClient.ReadCACertFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	options = append(options, cfClient.WithCACertificates(caCertPEM))
+	if d.Get("insecure_skip_tls_verify").(bool) {
+		log.Printf("[WARN] The certificate of %s isn't verified, insecure_skip_tls_verify must only be used for testing", apiURL)
+		options = append(options, cfClient.WithInsecureSkipTLSVerify())
+	}
+
+	client, err := cfClient.New(apiURL, options...)
+	if err != nil {
+		return nil, err
+	}
+	err = checkExpectedAccount(client, d.Get("expected_account_name").(string), d.Get("expected_account_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	configuredProviderSettings.Store(client, &providerSettings{
+		strictDeprecations:     d.Get("strict_deprecations").(bool),
+		defaultDecryptContexts: d.Get("default_decrypt_contexts").(bool),
+		adoptExisting:          d.Get("adopt_existing").(bool),
+		validateTagsExist:      expandValidateTagsExist(d.Get("validate_tags_exist").(string)),
+		pipelineSpecDefaults:   expandPipelineSpecDefaults(d.Get("pipeline_spec_defaults").([]interface{})),
+		pipelineStepAllowList:  expandPipelineStepAllowList(d.Get("pipeline_step_allow_list").([]interface{})),
+		defaultTags:            expandDefaultTags(d.Get("default_tags").([]interface{})),
+	})
+	return client, nil
+}
+
+// checkExpectedAccount verifies that the token belongs to the expected account, so that a configuration
+// pointed at the wrong account by mistake, e.g. with the token of another environment, fails before any change
+func checkExpectedAccount(client cfClient.CodefreshAPI, expectedName string, expectedID string) error {
+	if expectedName == "" && expectedID == "" {
+		return nil
+	}
+	account, err := client.GetCurrentAccount()
+	if err != nil {
+		return fmt.Errorf("unable to get the account of the token to check expected_account_name/expected_account_id: %v", err)
+	}
+	if expectedName != "" && account.Name != expectedName {
+		return fmt.Errorf("the token belongs to the account %s (%s), expected the account %s", account.Name, account.ID, expectedName)
+	}
+	if expectedID != "" && account.ID != expectedID {
+		return fmt.Errorf("the token belongs to the account %s (%s), expected the account id %s", account.Name, account.ID, expectedID)
 	}
-	return cfClient.NewClient(apiURL, token, ""), nil
+	return nil
 }
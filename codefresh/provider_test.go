@@ -2,8 +2,10 @@ package codefresh
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +25,69 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// currentAccountAPI mocks the account of the token
+type currentAccountAPI struct {
+	cfClient.CodefreshAPI
+	account cfClient.CurrentAccount
+}
+
+func (api *currentAccountAPI) GetCurrentAccount() (*cfClient.CurrentAccount, error) {
+	return &api.account, nil
+}
+
+func TestCheckExpectedAccount(t *testing.T) {
+	api := &currentAccountAPI{account: cfClient.CurrentAccount{ID: "5f1fd9044d0fc94ddf9c5ce1", Name: "production"}}
+
+	cases := []struct {
+		name, expectedName, expectedID string
+		expectError                    bool
+	}{
+		{"no expectation", "", "", false},
+		{"expected name", "production", "", false},
+		{"expected name and id", "production", "5f1fd9044d0fc94ddf9c5ce1", false},
+		{"wrong name", "staging", "", true},
+		{"wrong id", "", "5f1fd9a54d0fc9b1d39c5ce2", true},
+	}
+
+	for _, c := range cases {
+		err := checkExpectedAccount(api, c.expectedName, c.expectedID)
+		if (err != nil) != c.expectError {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
+
+func TestGetProviderToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"token": "inline-token"}, "inline-token"},
+		{map[string]interface{}{"token_file": tokenFile}, "file-token"},
+		{map[string]interface{}{"token_command": []interface{}{"echo", "command-token"}}, "command-token"},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+		token, err := getProviderToken(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != c.expected {
+			t.Errorf("Expected the token %s, got %s", c.expected, token)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"token_command": []interface{}{"true"}})
+	if _, err := getProviderToken(d); err == nil {
+		t.Error("Expected an error for an empty token")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("CODEFRESH_API_KEY"); v == "" {
 		t.Fatal("CODEFRESH_API_KEY must be set for acceptance tests")
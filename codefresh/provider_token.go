@@ -0,0 +1,64 @@
+package codefresh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tokenCommandTimeout the time given to the credential helper of token_command to print the token
+const tokenCommandTimeout = 30 * time.Second
+
+// getProviderToken returns the API token of the provider, read from token, token_file or the output of token_command,
+// and otherwise from the CODEFRESH_API_KEY environment variable
+func getProviderToken(d *schema.ResourceData) (string, error) {
+	if token := d.Get("token").(string); token != "" {
+		return token, nil
+	}
+
+	if tokenFile := d.Get("token_file").(string); tokenFile != "" {
+		content, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the token file %s: %v", tokenFile, err)
+		}
+		return nonEmptyToken(string(content), "token file "+tokenFile)
+	}
+
+	if command := convertStringArr(d.Get("token_command").([]interface{})); len(command) > 0 {
+		return runTokenCommand(command)
+	}
+
+	return os.Getenv("CODEFRESH_API_KEY"), nil
+}
+
+// runTokenCommand runs the credential helper without a shell and returns the token it prints on its standard output
+func runTokenCommand(command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("token_command %s failed: %v %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nonEmptyToken(stdout.String(), "output of token_command "+command[0])
+}
+
+// nonEmptyToken trims the trailing new line of the token and fails when it is empty
+func nonEmptyToken(token string, source string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("the %s is empty", source)
+	}
+	return token, nil
+}
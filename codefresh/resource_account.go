@@ -1,24 +1,36 @@
 package codefresh
 
 import (
+	"context"
+	"time"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountCreate,
-		Read:   resourceAccountRead,
-		Update: resourceAccountUpdate,
-		Delete: resourceAccountDelete,
+		CreateContext: resourceAccountCreate,
+		ReadContext:   resourceAccountRead,
+		UpdateContext: resourceAccountUpdate,
+		DeleteContext: resourceAccountDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"wait_for_ready": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			// "admins": {
 			// 	Type:     schema.TypeSet,
 			// 	Optional: true,
@@ -79,25 +91,32 @@ func resourceAccount() *schema.Resource {
 	}
 }
 
-func resourceAccountCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	account := *mapResourceToAccount(d)
 
 	resp, err := client.CreateAccount(&account)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(resp.ID)
 
+	if d.Get("wait_for_ready").(bool) {
+		err = client.WaitForAccountReady(resp.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
-func resourceAccountRead(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	accountID := d.Id()
 	if accountID == "" {
@@ -107,37 +126,37 @@ func resourceAccountRead(d *schema.ResourceData, meta interface{}) error {
 
 	team, err := client.GetAccountByID(accountID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapAccountToResource(team, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceAccountUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	account := *mapResourceToAccount(d)
 
 	_, err := client.UpdateAccount(&account)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	err := client.DeleteAccount(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
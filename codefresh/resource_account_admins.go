@@ -1,16 +1,19 @@
 package codefresh
 
 import (
+	"context"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceAccountAdmins() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountAdminsCreate,
-		Read:   resourceAccountAdminsRead,
-		Update: resourceAccountAdminsUpdate,
-		Delete: resourceAccountAdminsDelete,
+		CreateContext: resourceAccountAdminsCreate,
+		ReadContext:   resourceAccountAdminsRead,
+		UpdateContext: resourceAccountAdminsUpdate,
+		DeleteContext: resourceAccountAdminsDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -30,9 +33,9 @@ func resourceAccountAdmins() *schema.Resource {
 	}
 }
 
-func resourceAccountAdminsCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountAdminsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	admins := d.Get("users").(*schema.Set).List()
 
@@ -41,7 +44,7 @@ func resourceAccountAdminsCreate(d *schema.ResourceData, meta interface{}) error
 	for _, admin := range convertStringArr(admins) {
 		err := client.SetUserAsAccountAdmin(accountId, admin)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -51,9 +54,9 @@ func resourceAccountAdminsCreate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceAccountAdminsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountAdminsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	admins := d.Get("users").(*schema.Set).List()
 
@@ -62,16 +65,16 @@ func resourceAccountAdminsDelete(d *schema.ResourceData, meta interface{}) error
 	for _, admin := range convertStringArr(admins) {
 		err := client.DeleteUserAsAccountAdmin(accountId, admin)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceAccountAdminsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountAdminsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	accountId := d.Id()
 
@@ -83,22 +86,22 @@ func resourceAccountAdminsRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	err = d.Set("users", account.Admins)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceAccountAdminsUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountAdminsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	accountId := d.Get("account_id").(string)
 	desiredAdmins := d.Get("users").(*schema.Set).List()
 
 	account, err := client.GetAccountByID(accountId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	adminsToAdd, AdminsToDelete := cfClient.GetAccountAdminsDiff(convertStringArr(desiredAdmins), account.Admins)
@@ -106,14 +109,14 @@ func resourceAccountAdminsUpdate(d *schema.ResourceData, meta interface{}) error
 	for _, userId := range AdminsToDelete {
 		err := client.DeleteUserAsAccountAdmin(accountId, userId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	for _, userId := range adminsToAdd {
 		err := client.SetUserAsAccountAdmin(accountId, userId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -0,0 +1,143 @@
+package codefresh
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// saasRuntimeEnvironmentPrefix the prefix of the runtime environments hosted by Codefresh,
+// the hybrid runtime environments are named after the cluster and namespace of the runner
+const saasRuntimeEnvironmentPrefix = "system/"
+
+func resourceAccountRuntimeAllocation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountRuntimeAllocationCreate,
+		ReadContext:   resourceAccountRuntimeAllocationRead,
+		UpdateContext: resourceAccountRuntimeAllocationUpdate,
+		DeleteContext: resourceAccountRuntimeAllocationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"default_runtime_environment": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"hybrid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAccountRuntimeAllocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	account := mapResourceToAccountRuntimeAllocation(d)
+	if account.ID == "" {
+		// the default runtime environment of the account of the token
+		currentAccount, err := client.GetCurrentAccount()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		account.ID = currentAccount.ID
+	}
+
+	_, err := client.UpdateAccount(account)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(account.ID)
+
+	return resourceAccountRuntimeAllocationRead(ctx, d, meta)
+}
+
+func resourceAccountRuntimeAllocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	accountID := d.Id()
+	if accountID == "" {
+		d.SetId("")
+		return nil
+	}
+
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mapAccountRuntimeAllocationToResource(account, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountRuntimeAllocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	_, err := client.UpdateAccount(mapResourceToAccountRuntimeAllocation(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountRuntimeAllocationRead(ctx, d, meta)
+}
+
+// resourceAccountRuntimeAllocationDelete only removes the resource from the state,
+// an account always has a default runtime environment so the current one is kept
+func resourceAccountRuntimeAllocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] The default runtime environment of the account %s is kept as %s", d.Id(), d.Get("default_runtime_environment"))
+	return nil
+}
+
+func mapAccountRuntimeAllocationToResource(account *cfClient.Account, d *schema.ResourceData) error {
+
+	err := d.Set("account_id", account.ID)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("default_runtime_environment", account.RuntimeEnvironment)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("hybrid", isHybridRuntimeEnvironment(account.RuntimeEnvironment))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceToAccountRuntimeAllocation(d *schema.ResourceData) *cfClient.Account {
+	return &cfClient.Account{
+		ID:                 d.Get("account_id").(string),
+		RuntimeEnvironment: d.Get("default_runtime_environment").(string),
+	}
+}
+
+// isHybridRuntimeEnvironment returns whether the builds run on a runner installed in the customer's cluster
+func isHybridRuntimeEnvironment(name string) bool {
+	return name != "" && !strings.HasPrefix(name, saasRuntimeEnvironmentPrefix)
+}
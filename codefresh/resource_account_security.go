@@ -0,0 +1,178 @@
+package codefresh
+
+import (
+	"context"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAccountSecurity() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountSecurityCreate,
+		ReadContext:   resourceAccountSecurityRead,
+		UpdateContext: resourceAccountSecurityUpdate,
+		DeleteContext: resourceAccountSecurityDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"session_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0, // zero is the platform default
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"enforce_mfa": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"allowed_domains": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceAccountSecurityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	accountID := d.Get("account_id").(string)
+	allowedDomains, err := getAllowedDomains(client, accountID, d, "allowed_domains")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.UpdateAccountSecurity(accountID, mapResourceToAccountSecurity(d), allowedDomains)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(accountID)
+
+	return resourceAccountSecurityRead(ctx, d, meta)
+}
+
+func resourceAccountSecurityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	accountID := d.Id()
+	if accountID == "" {
+		d.SetId("")
+		return nil
+	}
+
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mapAccountSecurityToResource(account, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountSecurityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	allowedDomains, err := getAllowedDomains(client, d.Id(), d, "allowed_domains")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.UpdateAccountSecurity(d.Id(), mapResourceToAccountSecurity(d), allowedDomains)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountSecurityRead(ctx, d, meta)
+}
+
+// resourceAccountSecurityDelete restores the default security settings, as the settings themselves can't be deleted.
+// The allowed domains are kept, they may be managed by codefresh_account_sso_enforcement
+func resourceAccountSecurityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	account, err := client.GetAccountByID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.UpdateAccountSecurity(d.Id(), &cfClient.AccountSecurity{}, account.GetAllowedDomains())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func mapAccountSecurityToResource(account *cfClient.Account, d *schema.ResourceData) error {
+
+	err := d.Set("account_id", account.ID)
+	if err != nil {
+		return err
+	}
+
+	security := cfClient.AccountSecurity{}
+	if account.Security != nil {
+		security = *account.Security
+	}
+
+	err = d.Set("session_ttl", security.SessionTTL)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("enforce_mfa", security.EnforceMFA)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("allowed_domains", account.GetAllowedDomains())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceToAccountSecurity(d *schema.ResourceData) *cfClient.AccountSecurity {
+	return &cfClient.AccountSecurity{
+		SessionTTL: d.Get("session_ttl").(int),
+		EnforceMFA: d.Get("enforce_mfa").(bool),
+	}
+}
+
+// getAllowedDomains returns the allowed email domains configured in the attribute, or the current domains of
+// the account when they aren't configured: they are shared by codefresh_account_security and
+// codefresh_account_sso_enforcement, and are managed by at most one of them
+func getAllowedDomains(client cfClient.CodefreshAPI, accountID string, d *schema.ResourceData, attribute string) ([]string, error) {
+	if domains, ok := d.GetOk(attribute); ok {
+		return convertStringArr(domains.(*schema.Set).List()), nil
+	}
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	return account.GetAllowedDomains(), nil
+}
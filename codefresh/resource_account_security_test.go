@@ -0,0 +1,62 @@
+package codefresh
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accountSecurityAPI mocks an account whose security settings are overwritten by the updates
+type accountSecurityAPI struct {
+	cfClient.CodefreshAPI
+	account cfClient.Account
+}
+
+func (api *accountSecurityAPI) WithContext(ctx context.Context) cfClient.CodefreshAPI {
+	return api
+}
+
+func (api *accountSecurityAPI) GetAccountByID(id string) (*cfClient.Account, error) {
+	account := api.account
+	return &account, nil
+}
+
+func (api *accountSecurityAPI) UpdateAccountSecurity(accountID string, security *cfClient.AccountSecurity, allowedDomains []string) error {
+	api.account.Security = security
+	api.account.AllowedDomains = nil
+	for _, domain := range allowedDomains {
+		api.account.AllowedDomains = append(api.account.AllowedDomains, domain)
+	}
+	return nil
+}
+
+func TestAccountSecurityKeepsAllowedDomains(t *testing.T) {
+	api := &accountSecurityAPI{account: cfClient.Account{ID: "account", AllowedDomains: []interface{}{"example.com"}}}
+	d := schema.TestResourceDataRaw(t, resourceAccountSecurity().Schema, map[string]interface{}{
+		"account_id":  "account",
+		"enforce_mfa": true,
+	})
+
+	if diags := resourceAccountSecurityCreate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	if !api.account.Security.EnforceMFA {
+		t.Error("Expected MFA to be enforced")
+	}
+	if domains := api.account.GetAllowedDomains(); !reflect.DeepEqual(domains, []string{"example.com"}) {
+		t.Errorf("Expected the allowed domains of the account to be kept, got %v", domains)
+	}
+
+	if diags := resourceAccountSecurityDelete(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	if api.account.Security.EnforceMFA {
+		t.Error("Expected the default security settings to be restored")
+	}
+	if domains := api.account.GetAllowedDomains(); !reflect.DeepEqual(domains, []string{"example.com"}) {
+		t.Errorf("Expected the allowed domains of the account to be kept on delete, got %v", domains)
+	}
+}
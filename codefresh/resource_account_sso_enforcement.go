@@ -0,0 +1,144 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAccountSSOEnforcement() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountSSOEnforcementCreate,
+		ReadContext:   resourceAccountSSOEnforcementRead,
+		UpdateContext: resourceAccountSSOEnforcementUpdate,
+		DeleteContext: resourceAccountSSOEnforcementDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"disable_password_login": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"allowed_email_domains": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceAccountSSOEnforcementCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	accountID := d.Get("account_id").(string)
+
+	err := updateAccountSSOEnforcement(meta.(cfClient.CodefreshAPI).WithContext(ctx), accountID, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(accountID)
+
+	return resourceAccountSSOEnforcementRead(ctx, d, meta)
+}
+
+func resourceAccountSSOEnforcementRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	accountID := d.Id()
+	if accountID == "" {
+		d.SetId("")
+		return nil
+	}
+
+	account, err := client.GetAccountByID(accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("account_id", account.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("disable_password_login", !account.LocalUserPasswordIDPEnabled)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("allowed_email_domains", account.GetAllowedDomains()))
+}
+
+func resourceAccountSSOEnforcementUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	err := updateAccountSSOEnforcement(meta.(cfClient.CodefreshAPI).WithContext(ctx), d.Id(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountSSOEnforcementRead(ctx, d, meta)
+}
+
+// resourceAccountSSOEnforcementDelete allows the password logins again, the allowed email domains are kept
+func resourceAccountSSOEnforcementDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	account, err := client.GetAccountByID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(client.UpdateAccountSSOEnforcement(d.Id(), true, account.GetAllowedDomains()))
+}
+
+// updateAccountSSOEnforcement applies the settings of the resource, the allowed email domains of the account
+// are kept when they aren't configured, e.g. because they are managed by codefresh_account_security
+func updateAccountSSOEnforcement(client cfClient.CodefreshAPI, accountID string, d *schema.ResourceData) error {
+
+	disablePasswordLogin := d.Get("disable_password_login").(bool)
+	if disablePasswordLogin {
+		err := checkAccountHasIDP(client, accountID)
+		if err != nil {
+			return err
+		}
+	}
+
+	allowedDomains, err := getAllowedDomains(client, accountID, d, "allowed_email_domains")
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateAccountSSOEnforcement(accountID, !disablePasswordLogin, allowedDomains)
+}
+
+// checkAccountHasIDP prevents disabling the password logins of an account without SSO, which would lock its users out
+func checkAccountHasIDP(client cfClient.CodefreshAPI, accountID string) error {
+	idps, err := client.GetIDPs()
+	if err != nil {
+		return err
+	}
+	for _, idp := range *idps {
+		if cfClient.FindInSlice(idp.Accounts, accountID) {
+			log.Printf("[DEBUG] The users of the account %s log in with the IDP %s", accountID, idp.ClientName)
+			return nil
+		}
+	}
+	return fmt.Errorf("the account %s has no IDP, disabling the password logins would lock its users out. "+
+		"Add the account to an IDP with codefresh_idp_accounts first", accountID)
+}
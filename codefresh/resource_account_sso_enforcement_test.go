@@ -0,0 +1,56 @@
+package codefresh
+
+import (
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ssoAccountAPI mocks an account and the IDPs of the platform
+type ssoAccountAPI struct {
+	cfClient.CodefreshAPI
+	account       cfClient.Account
+	idps          []cfClient.IDP
+	passwordLogin bool
+	domains       []string
+}
+
+func (api *ssoAccountAPI) GetIDPs() (*[]cfClient.IDP, error) {
+	return &api.idps, nil
+}
+
+func (api *ssoAccountAPI) GetAccountByID(id string) (*cfClient.Account, error) {
+	return &api.account, nil
+}
+
+func (api *ssoAccountAPI) UpdateAccountSSOEnforcement(accountID string, passwordLogin bool, allowedDomains []string) error {
+	api.passwordLogin = passwordLogin
+	api.domains = allowedDomains
+	return nil
+}
+
+func TestUpdateAccountSSOEnforcement(t *testing.T) {
+	api := &ssoAccountAPI{
+		account:       cfClient.Account{ID: "account", AllowedDomains: []interface{}{"example.com"}},
+		passwordLogin: true,
+	}
+	d := schema.TestResourceDataRaw(t, resourceAccountSSOEnforcement().Schema, map[string]interface{}{
+		"account_id": "account",
+	})
+
+	if err := updateAccountSSOEnforcement(api, "account", d); err == nil || !api.passwordLogin {
+		t.Fatal("Expected the password logins of an account without IDP to be kept")
+	}
+
+	api.idps = []cfClient.IDP{{ClientName: "okta", Accounts: []string{"account"}}}
+	if err := updateAccountSSOEnforcement(api, "account", d); err != nil {
+		t.Fatal(err)
+	}
+	if api.passwordLogin {
+		t.Error("Expected the password logins to be disabled")
+	}
+	if len(api.domains) != 1 || api.domains[0] != "example.com" {
+		t.Errorf("Expected the allowed domains to be kept, got %v", api.domains)
+	}
+}
@@ -1,18 +1,20 @@
 package codefresh
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceApiKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApiKeyCreate,
-		Read:   resourceApiKeyRead,
-		Update: resourceApiKeyUpdate,
-		Delete: resourceApiKeyDelete,
+		CreateContext: resourceApiKeyCreate,
+		ReadContext:   resourceApiKeyRead,
+		UpdateContext: resourceApiKeyUpdate,
+		DeleteContext: resourceApiKeyDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -45,8 +47,8 @@ func resourceApiKey() *schema.Resource {
 	}
 }
 
-func resourceApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	apiKey := *mapResourceToApiKey(d)
 	accountID := d.Get("account_id").(string)
@@ -55,19 +57,17 @@ func resourceApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	resp, err := client.CreateApiKey(userID, accountID, &apiKey)
 	if err != nil {
 		fmt.Println(string(resp))
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("token", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	client.Token = resp
-
-	apiKeys, err := client.GetApiKeysList()
+	apiKeys, err := client.WithToken(resp).GetApiKeysList()
 	if err != nil {
-		return nil
+		return diag.Errorf("[ERROR] The API key %s was created but its ID can't be found, delete it manually. Error = %v", apiKey.Name, err)
 	}
 
 	var keyID string
@@ -78,7 +78,7 @@ func resourceApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if keyID == "" {
-		return errors.New("[ERROR] Key ID is not found.")
+		return diag.Errorf("[ERROR] The API key %s was created but its ID can't be found, delete it manually", apiKey.Name)
 	}
 
 	d.SetId(keyID)
@@ -86,9 +86,9 @@ func resourceApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceApiKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	keyID := d.Id()
 	if keyID == "" {
@@ -99,55 +99,51 @@ func resourceApiKeyRead(d *schema.ResourceData, meta interface{}) error {
 	token := d.Get("token").(string)
 
 	if token == "" {
-		return errors.New("[ERROR] Can't read API Key. Token is empty.")
+		return diag.FromErr(errors.New("[ERROR] Can't read API Key. Token is empty."))
 	}
 
-	client.Token = token
-
-	apiKey, err := client.GetAPIKey(keyID)
+	apiKey, err := client.WithToken(token).GetAPIKey(keyID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapApiKeyToResource(apiKey, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceApiKeyUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	apiKey := *mapResourceToApiKey(d)
 
 	token := d.Get("token").(string)
 	if token == "" {
-		return errors.New("[ERROR] Can't read API Key. Token is empty.")
+		return diag.FromErr(errors.New("[ERROR] Can't read API Key. Token is empty."))
 	}
 
-	client.Token = token
-
-	err := client.UpdateAPIKey(&apiKey)
+	err := client.WithToken(token).UpdateAPIKey(&apiKey)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceApiKeyDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	token := d.Get("token").(string)
 	if token == "" {
-		return errors.New("[ERROR] Can't read API Key. Token is empty.")
+		return diag.FromErr(errors.New("[ERROR] Can't read API Key. Token is empty."))
 	}
 
 	err := client.DeleteAPIKey(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
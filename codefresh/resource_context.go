@@ -1,18 +1,31 @@
 package codefresh
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/ghodss/yaml"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	contextConfig     = "config"
-	contextSecret     = "secret"
-	contextYaml       = "yaml"
-	contextSecretYaml = "secret-yaml"
+	contextConfig        = "config"
+	contextSecret        = "secret"
+	contextYaml          = "yaml"
+	contextSecretYaml    = "secret-yaml"
+	contextGoogleStorage = "storage.gc"
+	contextKubernetes    = "kubernetes"
 )
 
 var supportedContextType = []string{
@@ -20,6 +33,8 @@ var supportedContextType = []string{
 	contextSecret,
 	contextYaml,
 	contextSecretYaml,
+	contextGoogleStorage,
+	contextKubernetes,
 }
 
 func getConflictingContexts(context string) []string {
@@ -36,19 +51,35 @@ func getConflictingContexts(context string) []string {
 
 func resourceContext() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceContextCreate,
-		Read:   resourceContextRead,
-		Update: resourceContextUpdate,
-		Delete: resourceContextDelete,
+		CreateContext: resourceContextCreate,
+		ReadContext:   resourceContextRead,
+		UpdateContext: resourceContextUpdate,
+		DeleteContext: resourceContextDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importContextState,
 		},
+		CustomizeDiff: resourceContextCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"adopt_existing": adoptExistingSchema(),
+			"validate_credentials": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"decrypt_spec": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"detach_from_pipelines": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"spec": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -70,6 +101,14 @@ func resourceContext() *schema.Resource {
 											Type: schema.TypeString,
 										},
 									},
+									"encrypted_keys": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
 								},
 							},
 						},
@@ -83,11 +122,43 @@ func resourceContext() *schema.Resource {
 								Schema: map[string]*schema.Schema{
 									"data": {
 										Type:      schema.TypeMap,
-										Required:  true,
+										Optional:  true,
 										Sensitive: true,
 										Elem: &schema.Schema{
 											Type: schema.TypeString,
 										},
+										AtLeastOneOf: []string{
+											"spec.0." + normalizeFieldName(contextSecret) + ".0.data",
+											"spec.0." + normalizeFieldName(contextSecret) + ".0.data_base64",
+											"spec.0." + normalizeFieldName(contextSecret) + ".0.data_wo",
+										},
+									},
+									// the binary values, e.g. keystores, are sent base64 encoded as the API only stores strings
+									"data_base64": {
+										Type:      schema.TypeMap,
+										Optional:  true,
+										Sensitive: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringIsBase64,
+										},
+									},
+									"data_wo": {
+										Type:             schema.TypeMap,
+										Optional:         true,
+										Sensitive:        true,
+										DiffSuppressFunc: suppressWriteOnlyDiff("spec.0." + normalizeFieldName(contextSecret) + ".0.data_wo_version"),
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+										ConflictsWith: []string{
+											"spec.0." + normalizeFieldName(contextSecret) + ".0.data",
+											"spec.0." + normalizeFieldName(contextSecret) + ".0.data_base64",
+										},
+									},
+									"data_wo_version": {
+										Type:     schema.TypeInt,
+										Optional: true,
 									},
 								},
 							},
@@ -135,6 +206,58 @@ func resourceContext() *schema.Resource {
 								},
 							},
 						},
+						// the storage context is updated in place, so that rotating the service account key
+						// doesn't recreate the context and break the links to the reports stored with it
+						normalizeFieldName(contextGoogleStorage): {
+							Type:          schema.TypeList,
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: getConflictingContexts(contextGoogleStorage),
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"sharing_policy": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "allUsersInAccount",
+										ValidateFunc: validation.StringInSlice([]string{"allUsersInAccount", "allAdminsInAccount"}, false),
+									},
+									"json_config": {
+										Type:             schema.TypeString,
+										Required:         true,
+										Sensitive:        true,
+										ValidateFunc:     validation.StringIsJSON,
+										DiffSuppressFunc: structure.SuppressJsonDiff,
+									},
+									"key_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						// the kubeconfig is updated in place as well, its tokens and certificates are rotated
+						// regularly and the pipelines refer to the context by name
+						normalizeFieldName(contextKubernetes): {
+							Type:          schema.TypeList,
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: getConflictingContexts(contextKubernetes),
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"kubeconfig": {
+										Type:             schema.TypeString,
+										Required:         true,
+										Sensitive:        true,
+										ValidateFunc:     stringIsYaml,
+										DiffSuppressFunc: suppressEquivalentYamlDiffs,
+									},
+									"namespace": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -142,22 +265,45 @@ func resourceContext() *schema.Resource {
 	}
 }
 
-func resourceContextCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceContextCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	context := *mapResourceToContext(d)
+
+	lookup := func() (string, error) {
+		existingContext, err := client.GetContextWithDecrypt(context.Metadata.Name, false)
+		if cfClient.IsNotFoundError(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return existingContext.Metadata.Name, nil
+	}
+	adoptExisting := getAdoptExisting(d, meta)
+	nameWasFree := adoptExisting || isEntityNameFree(lookup)
+
 	resp, err := client.CreateContext(&context)
+	if cfClient.IsConflictError(err) && adoptExisting {
+		log.Printf("[INFO] Adopting the existing context %s", context.Metadata.Name)
+		d.SetId(context.Metadata.Name)
+		return resourceContextUpdate(ctx, d, meta)
+	}
 	if err != nil {
 		log.Printf("[DEBUG] Error while creating context. Error = %v", err)
-		return err
+		name, err := reconcileCreatedEntity("context", context.Metadata.Name, nameWasFree, err, lookup)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp = &cfClient.Context{Metadata: cfClient.ContextMetadata{Name: name}}
 	}
 
 	d.SetId(resp.Metadata.Name)
-	return resourceContextRead(d, meta)
+	return readCreatedEntity(ctx, d, meta, resourceContextRead, resourceContextDelete)
 }
 
-func resourceContextRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceContextRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	contextName := d.Id()
 
@@ -166,49 +312,324 @@ func resourceContextRead(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	context, err := client.GetContext(contextName)
+	decrypt := getContextDecryptSpec(d, meta)
+	context, err := client.GetContextWithDecrypt(contextName, decrypt)
 	if err != nil {
 		log.Printf("[DEBUG] Error while getting context. Error = %v", contextName)
-		return err
+		return diag.FromErr(err)
+	}
+
+	if contextUsesWriteOnlyData(d) {
+		// the write-only data is never read back nor stored in the state
+		return diag.FromErr(clearContextWriteOnlyData(d))
+	}
+
+	if !decrypt && hasEncryptedValues(context.Spec) {
+		// the encrypted values can't be read back, keep the spec from the state
+		return diag.FromErr(d.Set("name", context.Metadata.Name))
 	}
 
 	err = mapContextToResource(*context, d)
 	if err != nil {
 		log.Printf("[DEBUG] Error while mapping context to resource. Error = %v", err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceContextUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceContextUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	context := *mapResourceToContext(d)
 	context.Metadata.Name = d.Id()
 
+	if contextUsesWriteOnlyData(d) && len(context.Spec.Data) == 0 {
+		// the write-only data was cleared from the state after the create, keep the current one
+		current, err := client.GetContextWithDecrypt(d.Id(), true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		context.Spec.Type = current.Spec.Type
+		context.Spec.Data = current.Spec.Data
+	}
+
 	_, err := client.UpdateContext(&context)
 	if err != nil {
 		log.Printf("[DEBUG] Error while updating context. Error = %v", err)
+		return diag.FromErr(err)
+	}
+
+	return resourceContextRead(ctx, d, meta)
+}
+
+func resourceContextDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	contextName := d.Id()
+	if d.Get("detach_from_pipelines").(bool) {
+		err := detachContextFromPipelines(client, contextName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// the pipelines just detached from the context may not be taken into account yet by the API
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.MaxElapsedTime = 5 * time.Second
+	err := backoff.Retry(func() error {
+		err := client.DeleteContext(contextName)
+		if err == nil {
+			return nil
+		}
+		if !d.Get("detach_from_pipelines").(bool) || cfClient.IsNotFoundError(err) {
+			return backoff.Permanent(err)
+		}
+		log.Printf("[DEBUG] Unable to delete context %s, retrying. Error = %v", contextName, err)
 		return err
+	}, expBackoff)
+
+	err = ignoreDeletedEntity("context", contextName, err, func() error {
+		_, err := client.GetContextWithDecrypt(contextName, false)
+		return err
+	})
+	if err == nil {
+		return nil
 	}
 
-	return resourceContextRead(d, meta)
+	pipelines, listErr := getPipelinesUsingContext(client, contextName)
+	if listErr != nil {
+		log.Printf("[DEBUG] Unable to list the pipelines using context %s. Error = %v", contextName, listErr)
+		return diag.FromErr(err)
+	}
+	if len(pipelines) > 0 {
+		return diag.Errorf("the context %s can't be deleted, it's used by the pipelines %s. "+
+			"Remove it from their spec.contexts and trigger contexts, or set detach_from_pipelines to remove it automatically: %v",
+			contextName, strings.Join(pipelines, ", "), err)
+	}
+	return diag.FromErr(err)
 }
 
-func resourceContextDelete(d *schema.ResourceData, meta interface{}) error {
+// getPipelinesUsingContext returns the names of the pipelines loading the context, in their spec or their triggers
+func getPipelinesUsingContext(client cfClient.CodefreshAPI, contextName string) ([]string, error) {
+	pipelines, err := client.GetPipelines(nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, pipeline := range pipelines {
+		if removeContextFromPipeline(&pipeline, contextName) {
+			res = append(res, pipeline.Metadata.Name)
+		}
+	}
+	return res, nil
+}
 
-	client := meta.(*cfClient.Client)
+// detachContextFromPipelines removes the context from the pipelines loading it, so that it can be deleted.
+// The list only finds the pipelines using the context, each of them is read again with decrypt under its lock
+// before the update, so that the update is based on the current pipeline with its encrypted variables
+func detachContextFromPipelines(client cfClient.CodefreshAPI, contextName string) error {
+	pipelines, err := client.GetPipelines(nil)
+	if err != nil {
+		return err
+	}
+	for _, pipeline := range pipelines {
+		if !isContextUsedByPipeline(pipeline, contextName) {
+			continue
+		}
+		err = detachContextFromPipeline(client, pipeline.Metadata.ID, contextName)
+		if err != nil {
+			return fmt.Errorf("unable to detach context %s from pipeline %s: %v", contextName, pipeline.Metadata.Name, err)
+		}
+	}
+	return nil
+}
+
+// detachContextFromPipeline removes the context from the current pipeline
+func detachContextFromPipeline(client cfClient.CodefreshAPI, pipelineID string, contextName string) error {
+	unlock := lockPipeline(pipelineID)
+	defer unlock()
 
-	err := client.DeleteContext(d.Id())
+	pipeline, err := client.GetPipelineWithDecrypt(pipelineID, true)
 	if err != nil {
 		return err
 	}
+	if !removeContextFromPipeline(pipeline, contextName) {
+		return nil
+	}
+	log.Printf("[INFO] Detaching context %s from pipeline %s", contextName, pipeline.Metadata.Name)
+	_, err = client.UpdatePipeline(pipeline)
+	return err
+}
+
+// isContextUsedByPipeline whether the spec or the triggers of the pipeline load the context
+func isContextUsedByPipeline(pipeline cfClient.Pipeline, contextName string) bool {
+	for _, context := range pipeline.Spec.Contexts {
+		if context == contextName {
+			return true
+		}
+	}
+	for _, trigger := range pipeline.Spec.Triggers {
+		if cfClient.FindInSlice(trigger.Contexts, contextName) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeContextFromPipeline removes the context from the spec and the triggers of the pipeline,
+// and returns whether the pipeline was using it
+func removeContextFromPipeline(pipeline *cfClient.Pipeline, contextName string) bool {
+	found := false
+
+	var contexts []interface{}
+	for _, context := range pipeline.Spec.Contexts {
+		if context == contextName {
+			found = true
+			continue
+		}
+		contexts = append(contexts, context)
+	}
+	pipeline.Spec.Contexts = contexts
+
+	for i, trigger := range pipeline.Spec.Triggers {
+		if cfClient.FindInSlice(trigger.Contexts, contextName) {
+			found = true
+			var triggerContexts []string
+			for _, context := range trigger.Contexts {
+				if context != contextName {
+					triggerContexts = append(triggerContexts, context)
+				}
+			}
+			pipeline.Spec.Triggers[i].Contexts = triggerContexts
+		}
+	}
+
+	return found
+}
+
+func resourceContextCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	secretKey := "spec.0." + normalizeFieldName(contextSecret)
+	if base64Data, ok := d.GetOk(secretKey + ".0.data_base64"); ok {
+		data := d.Get(secretKey + ".0.data").(map[string]interface{})
+		for key := range base64Data.(map[string]interface{}) {
+			if _, ok := data[key]; ok {
+				return fmt.Errorf("%s is set in both data and data_base64 of %s", key, secretKey)
+			}
+		}
+	}
+	if !d.Get("validate_credentials").(bool) {
+		return nil
+	}
+	jsonConfigKey := "spec.0." + normalizeFieldName(contextGoogleStorage) + ".0.json_config"
+	if jsonConfig, ok := d.GetOk(jsonConfigKey); ok && d.NewValueKnown(jsonConfigKey) {
+		if err := validateGoogleServiceAccountKey(jsonConfig.(string)); err != nil {
+			return fmt.Errorf("invalid %s: %v", jsonConfigKey, err)
+		}
+	}
+	return nil
+}
 
+// validateGoogleServiceAccountKey checks that the JSON key of a Google service account is complete and its private key
+// can be parsed, without calling Google, so that a truncated or wrong key is reported before the context is saved
+func validateGoogleServiceAccountKey(jsonConfig string) error {
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKeyID string `json:"private_key_id"`
+		PrivateKey   string `json:"private_key"`
+	}
+	if err := json.Unmarshal([]byte(jsonConfig), &key); err != nil {
+		return err
+	}
+	if key.Type != "service_account" {
+		return fmt.Errorf("expected a key of type service_account, got %q", key.Type)
+	}
+	if key.ClientEmail == "" || key.PrivateKeyID == "" {
+		return fmt.Errorf("client_email and private_key_id are required")
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return fmt.Errorf("private_key isn't a PEM encoded key")
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		return fmt.Errorf("private_key can't be parsed: %v", err)
+	}
 	return nil
 }
 
+// getContextDecryptSpec returns whether the context should be decrypted on read,
+// the provider's default_decrypt_contexts is used unless decrypt_spec is set on the resource
+func getContextDecryptSpec(d *schema.ResourceData, meta interface{}) bool {
+	if decrypt, ok := d.GetOkExists("decrypt_spec"); ok {
+		return decrypt.(bool)
+	}
+	return getProviderSettings(meta).defaultDecryptContexts
+}
+
+func hasEncryptedValues(spec cfClient.ContextSpec) bool {
+	switch spec.Type {
+	case contextSecret, contextSecretYaml, contextGoogleStorage, contextKubernetes:
+		return true
+	case contextConfig:
+		return len(spec.EncryptedKeys) > 0
+	}
+	return false
+}
+
+// contextUsesWriteOnlyData returns if the secret context is configured with data_wo instead of data
+func contextUsesWriteOnlyData(d *schema.ResourceData) bool {
+	secret := d.Get("spec.0." + normalizeFieldName(contextSecret)).([]interface{})
+	if len(secret) == 0 || secret[0] == nil {
+		return false
+	}
+	block := secret[0].(map[string]interface{})
+	return len(block["data"].(map[string]interface{})) == 0 && len(block["data_base64"].(map[string]interface{})) == 0
+}
+
+// getSecretContextData merges the string and the base64 encoded values of the secret context
+func getSecretContextData(d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{}
+	for key, value := range d.Get("spec.0." + normalizeFieldName(contextSecret) + ".0.data").(map[string]interface{}) {
+		data[key] = value
+	}
+	for key, value := range d.Get("spec.0." + normalizeFieldName(contextSecret) + ".0.data_base64").(map[string]interface{}) {
+		data[key] = value
+	}
+	return data
+}
+
+// splitSecretContextData moves the values of the keys configured in data_base64 from data to data_base64
+func splitSecretContextData(spec []interface{}, base64Keys map[string]interface{}) {
+	if len(spec) == 0 || spec[0] == nil {
+		return
+	}
+	secret, ok := spec[0].(map[string]interface{})[normalizeFieldName(contextSecret)].([]interface{})
+	if !ok || len(secret) == 0 {
+		return
+	}
+	block := secret[0].(map[string]interface{})
+	data, _ := block["data"].(map[string]string)
+	base64Data := map[string]string{}
+	for key := range base64Keys {
+		if value, ok := data[key]; ok {
+			base64Data[key] = value
+			delete(data, key)
+		}
+	}
+	block["data_base64"] = base64Data
+}
+
+// clearContextWriteOnlyData removes the values of data_wo from the state, only the version is kept
+func clearContextWriteOnlyData(d *schema.ResourceData) error {
+	spec := d.Get("spec").([]interface{})
+	secret := spec[0].(map[string]interface{})[normalizeFieldName(contextSecret)].([]interface{})
+	secret[0].(map[string]interface{})["data_wo"] = map[string]interface{}{}
+	return d.Set("spec", spec)
+}
+
 func mapContextToResource(context cfClient.Context, d *schema.ResourceData) error {
 
 	err := d.Set("name", context.Metadata.Name)
@@ -216,7 +637,11 @@ func mapContextToResource(context cfClient.Context, d *schema.ResourceData) erro
 		return err
 	}
 
-	err = d.Set("spec", flattenContextSpec(context.Spec))
+	spec := flattenContextSpec(context.Spec)
+	if context.Spec.Type == contextSecret {
+		splitSecretContextData(spec, d.Get("spec.0."+normalizeFieldName(contextSecret)+".0.data_base64").(map[string]interface{}))
+	}
+	err = d.Set("spec", spec)
 	if err != nil {
 		log.Printf("[DEBUG] Failed to flatten Context spec = %v", context.Spec)
 		return err
@@ -239,6 +664,10 @@ func flattenContextSpec(spec cfClient.ContextSpec) []interface{} {
 		m[normalizeFieldName(currentContextType)] = flattenContextConfig(spec)
 	case contextYaml, contextSecretYaml:
 		m[normalizeFieldName(currentContextType)] = flattenContextYaml(spec)
+	case contextGoogleStorage:
+		m[normalizeFieldName(currentContextType)] = flattenContextGoogleStorage(spec)
+	case contextKubernetes:
+		m[normalizeFieldName(currentContextType)] = flattenContextKubernetes(spec)
 	default:
 		log.Printf("[DEBUG] Invalid context type = %v", currentContextType)
 		return nil
@@ -251,7 +680,10 @@ func flattenContextSpec(spec cfClient.ContextSpec) []interface{} {
 func flattenContextConfig(spec cfClient.ContextSpec) []interface{} {
 	var res = make([]interface{}, 0)
 	m := make(map[string]interface{})
-	m["data"] = spec.Data
+	m["data"] = flattenStringMap("context data", spec.Data)
+	if spec.Type == contextConfig {
+		m["encrypted_keys"] = spec.EncryptedKeys
+	}
 	res = append(res, m)
 	return res
 }
@@ -268,15 +700,57 @@ func flattenContextYaml(spec cfClient.ContextSpec) []interface{} {
 	return res
 }
 
+func flattenContextGoogleStorage(spec cfClient.ContextSpec) []interface{} {
+	var res = make([]interface{}, 0)
+	m := make(map[string]interface{})
+	m["sharing_policy"] = spec.Data["sharingPolicy"]
+	if auth, ok := spec.Data["auth"].(map[string]interface{}); ok {
+		jsonConfig, err := json.Marshal(auth["jsonConfig"])
+		if err != nil {
+			return nil
+		}
+		m["json_config"] = string(jsonConfig)
+		m["key_id"] = getGoogleServiceAccountKeyID(auth["jsonConfig"])
+	}
+	res = append(res, m)
+	return res
+}
+
+func flattenContextKubernetes(spec cfClient.ContextSpec) []interface{} {
+	var res = make([]interface{}, 0)
+	m := make(map[string]interface{})
+	m["kubeconfig"] = spec.Data["kubeconfig"]
+	m["namespace"] = spec.Data["namespace"]
+	res = append(res, m)
+	return res
+}
+
+// getGoogleServiceAccountKeyID returns the id of the service account key, which changes on every rotation
+func getGoogleServiceAccountKeyID(jsonConfig interface{}) string {
+	if config, ok := jsonConfig.(map[string]interface{}); ok {
+		if keyID, ok := config["private_key_id"].(string); ok {
+			return keyID
+		}
+	}
+	return ""
+}
+
 func mapResourceToContext(d *schema.ResourceData) *cfClient.Context {
 
 	var normalizedContextType string
 	var normalizedContextData map[string]interface{}
+	var encryptedKeys []string
 
 	if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextConfig) + ".0.data"); ok {
 		normalizedContextType = contextConfig
 		normalizedContextData = data.(map[string]interface{})
-	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextSecret) + ".0.data"); ok {
+		if keys, ok := d.GetOk("spec.0." + normalizeFieldName(contextConfig) + ".0.encrypted_keys"); ok {
+			encryptedKeys = convertStringArr(keys.(*schema.Set).List())
+		}
+	} else if data := getSecretContextData(d); len(data) > 0 {
+		normalizedContextType = contextSecret
+		normalizedContextData = data
+	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextSecret) + ".0.data_wo"); ok {
 		normalizedContextType = contextSecret
 		normalizedContextData = data.(map[string]interface{})
 	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextYaml) + ".0.data"); ok {
@@ -285,6 +759,25 @@ func mapResourceToContext(d *schema.ResourceData) *cfClient.Context {
 	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextSecretYaml) + ".0.data"); ok {
 		normalizedContextType = contextSecretYaml
 		yaml.Unmarshal([]byte(data.(string)), &normalizedContextData)
+	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextGoogleStorage) + ".0.json_config"); ok {
+		normalizedContextType = contextGoogleStorage
+		var jsonConfig map[string]interface{}
+		json.Unmarshal([]byte(data.(string)), &jsonConfig)
+		normalizedContextData = map[string]interface{}{
+			"sharingPolicy": d.Get("spec.0." + normalizeFieldName(contextGoogleStorage) + ".0.sharing_policy").(string),
+			"auth": map[string]interface{}{
+				"type":       "basic",
+				"jsonConfig": jsonConfig,
+			},
+		}
+	} else if data, ok := d.GetOk("spec.0." + normalizeFieldName(contextKubernetes) + ".0.kubeconfig"); ok {
+		normalizedContextType = contextKubernetes
+		normalizedContextData = map[string]interface{}{
+			"kubeconfig": data.(string),
+		}
+		if namespace, ok := d.GetOk("spec.0." + normalizeFieldName(contextKubernetes) + ".0.namespace"); ok {
+			normalizedContextData["namespace"] = namespace.(string)
+		}
 	}
 
 	context := &cfClient.Context{
@@ -292,8 +785,9 @@ func mapResourceToContext(d *schema.ResourceData) *cfClient.Context {
 			Name: d.Get("name").(string),
 		},
 		Spec: cfClient.ContextSpec{
-			Type: normalizedContextType,
-			Data: normalizedContextData,
+			Type:          normalizedContextType,
+			Data:          normalizedContextData,
+			EncryptedKeys: encryptedKeys,
 		},
 	}
 
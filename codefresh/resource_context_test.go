@@ -1,13 +1,22 @@
 package codefresh
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -67,6 +76,34 @@ func TestAccCodefreshContextConfig(t *testing.T) {
 	})
 }
 
+func TestAccCodefreshContextConfigEncryptedKeys(t *testing.T) {
+	name := contextNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_context.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshContextDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshContextConfigEncryptedKeys(name, "config1", "value1", "config2", "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshContextExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.config.0.data.config1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.config.0.data.config2", "value2"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.config.0.encrypted_keys.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccCodefreshContextSecret(t *testing.T) {
 	name := contextNamePrefix + acctest.RandString(10)
 	resourceName := "codefresh_context.test"
@@ -145,6 +182,295 @@ func TestAccCodefreshContextSecretYaml(t *testing.T) {
 	})
 }
 
+func TestAccCodefreshContextGoogleStorage(t *testing.T) {
+	name := contextNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_context.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshContextDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshContextGoogleStorage(name, "key1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshContextExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.storagegc.0.key_id", "key1"),
+				),
+			},
+			{
+				// rotating the key updates the context in place
+				Config: testAccCodefreshContextGoogleStorage(name, "key2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshContextExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.storagegc.0.key_id", "key2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCodefreshContextKubernetes(t *testing.T) {
+	name := contextNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_context.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshContextDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshContextKubernetes(name, "token1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshContextExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.kubernetes.0.namespace", "default"),
+				),
+			},
+			{
+				// rotating the token updates the context in place
+				Config: testAccCodefreshContextKubernetes(name, "token2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshContextExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+				),
+			},
+		},
+	})
+}
+
+// Unit Testing
+func TestGetContextDecryptSpec(t *testing.T) {
+	meta := cfClient.NewClient("", "", "", "")
+	configuredProviderSettings.Store(meta, &providerSettings{defaultDecryptContexts: false})
+	defer configuredProviderSettings.Delete(meta)
+
+	inherited := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{"name": "test"})
+	if getContextDecryptSpec(inherited, meta) {
+		t.Errorf("Expected the provider default to be inherited")
+	}
+
+	overridden := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{"name": "test", "decrypt_spec": true})
+	if !getContextDecryptSpec(overridden, meta) {
+		t.Errorf("Expected decrypt_spec to override the provider default")
+	}
+}
+
+func TestValidateGoogleServiceAccountKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	jsonKey := func(keyType, privateKey string) string {
+		key, _ := json.Marshal(map[string]string{
+			"type":           keyType,
+			"client_email":   "terraform@project.iam.gserviceaccount.com",
+			"private_key_id": "abc",
+			"private_key":    privateKey,
+		})
+		return string(key)
+	}
+
+	cases := []struct {
+		name        string
+		jsonConfig  string
+		expectError bool
+	}{
+		{"valid key", jsonKey("service_account", pemKey), false},
+		{"wrong type", jsonKey("authorized_user", pemKey), true},
+		{"truncated private key", jsonKey("service_account", pemKey[:100]), true},
+		{"missing fields", `{"type": "service_account"}`, true},
+	}
+
+	for _, c := range cases {
+		err := validateGoogleServiceAccountKey(c.jsonConfig)
+		if (err != nil) != c.expectError {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
+
+func TestContextWriteOnlyData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{
+		"name": "test",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"secret": []interface{}{
+					map[string]interface{}{
+						"data_wo":         map[string]interface{}{"password": "s3cr3t"},
+						"data_wo_version": 1,
+					},
+				},
+			},
+		},
+	})
+
+	if !contextUsesWriteOnlyData(d) {
+		t.Fatal("Expected the context to use write-only data")
+	}
+	context := mapResourceToContext(d)
+	if context.Spec.Type != contextSecret || context.Spec.Data["password"] != "s3cr3t" {
+		t.Fatalf("Unexpected context spec %v", context.Spec)
+	}
+
+	if err := clearContextWriteOnlyData(d); err != nil {
+		t.Fatal(err)
+	}
+	if data := d.Get("spec.0.secret.0.data_wo").(map[string]interface{}); len(data) != 0 {
+		t.Errorf("Expected the write-only data to be cleared, got %v", data)
+	}
+	if version := d.Get("spec.0.secret.0.data_wo_version").(int); version != 1 {
+		t.Errorf("Expected the version to be kept, got %d", version)
+	}
+}
+
+func TestContextBase64Data(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{
+		"name": "test",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"secret": []interface{}{
+					map[string]interface{}{
+						"data":        map[string]interface{}{"password": "s3cr3t"},
+						"data_base64": map[string]interface{}{"keystore": "AAEC/w=="},
+					},
+				},
+			},
+		},
+	})
+
+	if contextUsesWriteOnlyData(d) {
+		t.Fatal("Expected the context not to use write-only data")
+	}
+	context := mapResourceToContext(d)
+	if context.Spec.Data["password"] != "s3cr3t" || context.Spec.Data["keystore"] != "AAEC/w==" {
+		t.Fatalf("Unexpected context spec %v", context.Spec)
+	}
+
+	if err := mapContextToResource(*context, d); err != nil {
+		t.Fatal(err)
+	}
+	if data := d.Get("spec.0.secret.0.data").(map[string]interface{}); len(data) != 1 || data["password"] != "s3cr3t" {
+		t.Errorf("Unexpected data %v", data)
+	}
+	if data := d.Get("spec.0.secret.0.data_base64").(map[string]interface{}); len(data) != 1 || data["keystore"] != "AAEC/w==" {
+		t.Errorf("Unexpected data_base64 %v", data)
+	}
+}
+
+func TestMapResourceToContextKubernetes(t *testing.T) {
+	kubeconfig := "apiVersion: v1\nkind: Config\n"
+	d := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{
+		"name": "test",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"kubernetes": []interface{}{
+					map[string]interface{}{
+						"kubeconfig": kubeconfig,
+						"namespace":  "ci",
+					},
+				},
+			},
+		},
+	})
+
+	context := mapResourceToContext(d)
+	if context.Spec.Type != contextKubernetes {
+		t.Fatalf("Expected type %s, got %s", contextKubernetes, context.Spec.Type)
+	}
+	if context.Spec.Data["kubeconfig"] != kubeconfig || context.Spec.Data["namespace"] != "ci" {
+		t.Errorf("Unexpected context data %v", context.Spec.Data)
+	}
+
+	spec := flattenContextSpec(context.Spec)[0].(map[string]interface{})
+	flattened := spec["kubernetes"].([]interface{})[0].(map[string]interface{})
+	if flattened["kubeconfig"] != kubeconfig || flattened["namespace"] != "ci" {
+		t.Errorf("Unexpected flattened spec %v", flattened)
+	}
+}
+
+// contextInUseAPI mocks the API of an account where the context can't be deleted while pipelines use it
+type contextInUseAPI struct {
+	cfClient.CodefreshAPI
+	pipelines []cfClient.Pipeline
+	deleted   bool
+}
+
+func (api *contextInUseAPI) WithContext(ctx context.Context) cfClient.CodefreshAPI {
+	return api
+}
+
+func (api *contextInUseAPI) GetPipelines(filter *cfClient.PipelinesFilter) ([]cfClient.Pipeline, error) {
+	return api.pipelines, nil
+}
+
+func (api *contextInUseAPI) GetPipelineWithDecrypt(name string, decrypt bool) (*cfClient.Pipeline, error) {
+	for _, pipeline := range api.pipelines {
+		if pipeline.Metadata.ID == name {
+			return &pipeline, nil
+		}
+	}
+	return nil, &cfClient.APIError{StatusCode: 404}
+}
+
+func (api *contextInUseAPI) UpdatePipeline(pipeline *cfClient.Pipeline) (*cfClient.Pipeline, error) {
+	for i := range api.pipelines {
+		if api.pipelines[i].Metadata.ID == pipeline.Metadata.ID {
+			api.pipelines[i] = *pipeline
+		}
+	}
+	return pipeline, nil
+}
+
+func (api *contextInUseAPI) DeleteContext(name string) error {
+	for _, pipeline := range api.pipelines {
+		if removeContextFromPipeline(&pipeline, name) {
+			return &cfClient.APIError{StatusCode: 400, Body: "context is in use"}
+		}
+	}
+	api.deleted = true
+	return nil
+}
+
+func (api *contextInUseAPI) GetContextWithDecrypt(name string, decrypt bool) (*cfClient.Context, error) {
+	return &cfClient.Context{}, nil
+}
+
+func TestResourceContextDeleteInUse(t *testing.T) {
+	newAPI := func() *contextInUseAPI {
+		return &contextInUseAPI{pipelines: []cfClient.Pipeline{
+			{Metadata: cfClient.Metadata{ID: "1", Name: "project/build"}, Spec: cfClient.Spec{Contexts: []interface{}{"shared", "other"}}},
+			{Metadata: cfClient.Metadata{ID: "2", Name: "project/deploy"}, Spec: cfClient.Spec{Triggers: []cfClient.Trigger{{Name: "commits", Contexts: []string{"shared"}}}}},
+			{Metadata: cfClient.Metadata{ID: "3", Name: "project/test"}, Spec: cfClient.Spec{Contexts: []interface{}{"other"}}},
+		}}
+	}
+
+	api := newAPI()
+	d := schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{"name": "shared"})
+	d.SetId("shared")
+	diags := resourceContextDelete(context.Background(), d, api)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "project/build, project/deploy") {
+		t.Errorf("Expected an error listing the pipelines using the context, got %v", diags)
+	}
+
+	api = newAPI()
+	d = schema.TestResourceDataRaw(t, resourceContext().Schema, map[string]interface{}{"name": "shared", "detach_from_pipelines": true})
+	d.SetId("shared")
+	if diags := resourceContextDelete(context.Background(), d, api); diags.HasError() || !api.deleted {
+		t.Fatalf("Expected the context to be detached and deleted, got %v", diags)
+	}
+	if !reflect.DeepEqual(api.pipelines[0].Spec.Contexts, []interface{}{"other"}) || len(api.pipelines[1].Spec.Triggers[0].Contexts) != 0 {
+		t.Errorf("Expected the context to be removed from the pipelines, got %v", api.pipelines)
+	}
+}
+
 func testAccCheckCodefreshContextExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 
@@ -214,6 +540,26 @@ resource "codefresh_context" "test" {
 `, rName, dataKey1, dataValue1, dataKey2, dataValue2)
 }
 
+func testAccCodefreshContextConfigEncryptedKeys(rName, dataKey1, dataValue1, dataKey2, dataValue2 string) string {
+
+	return fmt.Sprintf(`
+resource "codefresh_context" "test" {
+
+  name = "%s"
+
+  spec {
+	config {
+		data = { 
+			%q = %q
+			%q = %q
+		}
+		encrypted_keys = [%q]
+	}
+  }
+}
+`, rName, dataKey1, dataValue1, dataKey2, dataValue2, dataKey2)
+}
+
 func testAccCodefreshContextSecret(rName, dataKey1, dataValue1, dataKey2, dataValue2 string) string {
 
 	return fmt.Sprintf(`
@@ -264,3 +610,71 @@ resource "codefresh_context" "test" {
 }
 `, rName, rootKey, plainKey, plainValue, listKey, listValue1, listValue2)
 }
+
+func testAccCodefreshContextGoogleStorage(rName, keyID string) string {
+	return fmt.Sprintf(`
+resource "codefresh_context" "test" {
+
+  name = "%s"
+
+  spec {
+	storagegc {
+		json_config = jsonencode({
+			type           = "service_account"
+			project_id     = "test-project"
+			private_key_id = %q
+			client_email   = "test@test-project.iam.gserviceaccount.com"
+		})
+	}
+  }
+}
+`, rName, keyID)
+}
+
+func testAccCodefreshContextKubernetes(rName, token string) string {
+	return fmt.Sprintf(`
+resource "codefresh_context" "test" {
+
+  name = "%s"
+
+  spec {
+	kubernetes {
+		kubeconfig = yamlencode({
+			apiVersion      = "v1"
+			kind            = "Config"
+			current-context = "test"
+			clusters        = [{ name = "test", cluster = { server = "https://kubernetes.example.com" } }]
+			users           = [{ name = "test", user = { token = %q } }]
+			contexts        = [{ name = "test", context = { cluster = "test", user = "test" } }]
+		})
+		namespace = "default"
+	}
+  }
+}
+`, rName, token)
+}
+
+func TestDetachContextFromPipelines(t *testing.T) {
+	api := &maskedPipelineAPI{pipeline: cfClient.Pipeline{
+		Metadata: cfClient.Metadata{ID: "5f1fd9044d0fc94ddf9c5ce1", Name: "project/pipeline"},
+		Spec: cfClient.Spec{
+			Contexts:  []interface{}{"shared", "removed"},
+			Variables: []cfClient.Variable{{Key: "TOKEN", Value: "spec-secret", Encrypted: true}},
+			Triggers: []cfClient.Trigger{{
+				Name:      "commits",
+				Contexts:  []string{"removed"},
+				Variables: []cfClient.Variable{{Key: "KEY", Value: "trigger-secret", Encrypted: true}},
+			}},
+		},
+	}}
+
+	if err := detachContextFromPipelines(api, "removed"); err != nil {
+		t.Fatal(err)
+	}
+	if api.updated == nil || !reflect.DeepEqual(api.updated.Spec.Contexts, []interface{}{"shared"}) || len(api.updated.Spec.Triggers[0].Contexts) != 0 {
+		t.Fatalf("Expected the context to be removed from the pipeline, got %+v", api.updated)
+	}
+	if values := encryptedValues(api.updated); fmt.Sprint(values) != "[spec-secret trigger-secret]" {
+		t.Errorf("Expected the pipeline read with decrypt to be updated, got %v", values)
+	}
+}
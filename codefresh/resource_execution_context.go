@@ -0,0 +1,164 @@
+package codefresh
+
+import (
+	"context"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceExecutionContext() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceExecutionContextCreate,
+		ReadContext:   resourceExecutionContextRead,
+		UpdateContext: resourceExecutionContextUpdate,
+		DeleteContext: resourceExecutionContextDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cloud_provider": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"aws", "gcp", "azure"}, false),
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"audience": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"session_duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(900, 43200),
+			},
+		},
+	}
+}
+
+func resourceExecutionContextCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	executionContext := *mapResourceToExecutionContext(d)
+
+	resp, err := client.CreateExecutionContext(&executionContext)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.ID)
+
+	return readCreatedEntity(ctx, d, meta, resourceExecutionContextRead, resourceExecutionContextDelete)
+}
+
+func resourceExecutionContextRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	executionContextID := d.Id()
+	if executionContextID == "" {
+		d.SetId("")
+		return nil
+	}
+
+	executionContext, err := client.GetExecutionContext(executionContextID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = mapExecutionContextToResource(executionContext, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceExecutionContextUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	err := client.UpdateExecutionContext(mapResourceToExecutionContext(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceExecutionContextRead(ctx, d, meta)
+}
+
+func resourceExecutionContextDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	err := client.DeleteExecutionContext(d.Id())
+	return diag.FromErr(ignoreDeletedEntity("execution context", d.Id(), err, func() error {
+		_, err := client.GetExecutionContext(d.Id())
+		return err
+	}))
+}
+
+func mapExecutionContextToResource(executionContext *cfClient.ExecutionContext, d *schema.ResourceData) error {
+
+	err := d.Set("name", executionContext.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("cloud_provider", executionContext.Spec.CloudProvider)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("role", executionContext.Spec.Role)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("audience", executionContext.Spec.Audience)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("region", executionContext.Spec.Region)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("session_duration", executionContext.Spec.SessionDuration)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceToExecutionContext(d *schema.ResourceData) *cfClient.ExecutionContext {
+	return &cfClient.ExecutionContext{
+		ID:   d.Id(),
+		Name: d.Get("name").(string),
+		Spec: cfClient.ExecutionContextSpec{
+			CloudProvider:   d.Get("cloud_provider").(string),
+			Role:            d.Get("role").(string),
+			Audience:        d.Get("audience").(string),
+			Region:          d.Get("region").(string),
+			SessionDuration: d.Get("session_duration").(int),
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package codefresh
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var genericEntityMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+func resourceGenericEntity() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGenericEntityCreate,
+		ReadContext:   resourceGenericEntityRead,
+		UpdateContext: resourceGenericEntityUpdate,
+		DeleteContext: resourceGenericEntityDelete,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+			},
+			"id_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "id",
+			},
+			"create_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "POST",
+				ValidateFunc: validation.StringInSlice(genericEntityMethods, false),
+			},
+			"update_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PUT",
+				ValidateFunc: validation.StringInSlice(genericEntityMethods, false),
+			},
+			"read_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"update_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delete_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"response": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGenericEntityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	entity, err := client.RequestGenericEntity(
+		d.Get("create_method").(string),
+		d.Get("path").(string),
+		[]byte(d.Get("body").(string)),
+		d.Get("id_path").(string),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(entity.ID)
+
+	return readCreatedEntity(ctx, d, meta, resourceGenericEntityRead, resourceGenericEntityDelete)
+}
+
+func resourceGenericEntityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	entity, err := client.RequestGenericEntity("GET", getGenericEntityPath(d, "read_path"), nil, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("response", entity.Response)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGenericEntityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	_, err := client.RequestGenericEntity(
+		d.Get("update_method").(string),
+		getGenericEntityPath(d, "update_path"),
+		[]byte(d.Get("body").(string)),
+		"",
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGenericEntityRead(ctx, d, meta)
+}
+
+func resourceGenericEntityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	_, err := client.RequestGenericEntity("DELETE", getGenericEntityPath(d, "delete_path"), nil, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getGenericEntityPath returns the path of the entity for the given operation with the id placeholder resolved.
+// When the path isn't set, it defaults to <path>/<id>
+func getGenericEntityPath(d *schema.ResourceData, attribute string) string {
+	path := d.Get(attribute).(string)
+	if path == "" {
+		path = strings.TrimSuffix(d.Get("path").(string), "/") + "/" + cfClient.GenericEntityIDPlaceholder
+	}
+	return strings.Replace(path, cfClient.GenericEntityIDPlaceholder, url.PathEscape(d.Id()), -1)
+}
@@ -0,0 +1,77 @@
+package codefresh
+
+import (
+	"fmt"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var genericEntityNamePrefix = "TerraformAccTest_"
+
+// The generic entity is tested against the contexts API, so the result can be verified with the context client
+func TestAccCodefreshGenericEntity_Context(t *testing.T) {
+	name := genericEntityNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_generic_entity.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshContextDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshGenericEntityContextConfig(name, "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", name),
+					testAccCheckCodefreshGenericEntityContextValue(name, "value1"),
+				),
+			},
+			{
+				Config: testAccCodefreshGenericEntityContextConfig(name, "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", name),
+					testAccCheckCodefreshGenericEntityContextValue(name, "value2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCodefreshGenericEntityContextValue(name, value string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		apiClient := testAccProvider.Meta().(*cfClient.Client)
+		context, err := apiClient.GetContext(name)
+		if err != nil {
+			return fmt.Errorf("error fetching context %s. %s", name, err)
+		}
+		if context.Spec.Data["var1"] != value {
+			return fmt.Errorf("expected var1 = %s, got %v", value, context.Spec.Data["var1"])
+		}
+		return nil
+	}
+}
+
+// CONFIGS
+func testAccCodefreshGenericEntityContextConfig(rName, value string) string {
+	return fmt.Sprintf(`
+resource "codefresh_generic_entity" "test" {
+  path    = "/contexts"
+  id_path = "metadata.name"
+
+  body = jsonencode({
+    metadata = {
+      name = %q
+    }
+    spec = {
+      type = "config"
+      data = {
+        var1 = %q
+      }
+    }
+  })
+}
+`, rName, value)
+}
@@ -0,0 +1,232 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGitopsCluster() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGitopsClusterCreate,
+		ReadContext:   resourceGitopsClusterRead,
+		UpdateContext: resourceGitopsClusterUpdate,
+		DeleteContext: resourceGitopsClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"runtime": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"server": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"bearer_token": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"bearer_token", "bearer_token_wo", "kubeconfig"},
+			},
+			"bearer_token_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressWriteOnlyDiff("credentials_wo_version"),
+			},
+			"credentials_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"kubeconfig": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: stringIsYaml,
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connection_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitopsClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	cluster := *mapResourceToGitopsCluster(d, true)
+
+	resp, err := client.AddGitopsCluster(&cluster)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.GetID())
+
+	// the write-only token is never stored in the state
+	err = d.Set("bearer_token_wo", "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readCreatedEntity(ctx, d, meta, resourceGitopsClusterRead, resourceGitopsClusterDelete)
+}
+
+func resourceGitopsClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	runtime, name, err := parseGitopsClusterID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cluster, err := client.GetGitopsCluster(runtime, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if cluster == nil {
+		d.SetId("")
+		return nil
+	}
+
+	err = mapGitopsClusterToResource(cluster, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGitopsClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	// the credentials are only sent when they change, e.g. on a token rotation
+	cluster := *mapResourceToGitopsCluster(d, d.HasChanges("bearer_token", "kubeconfig", "credentials_wo_version"))
+
+	err := client.UpdateGitopsCluster(&cluster)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("bearer_token_wo", "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGitopsClusterRead(ctx, d, meta)
+}
+
+func resourceGitopsClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	runtime, name, err := parseGitopsClusterID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.RemoveGitopsCluster(runtime, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// parseGitopsClusterID splits the id of the resource, <runtime>/<name>
+func parseGitopsClusterID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("[ERROR] Invalid GitOps cluster ID %s, expected <runtime>/<name>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// mapGitopsClusterToResource sets the cluster in the resource, the credentials are kept from the state
+// as the API never returns them
+func mapGitopsClusterToResource(cluster *cfClient.GitopsCluster, d *schema.ResourceData) error {
+
+	err := d.Set("name", cluster.Metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("runtime", cluster.Metadata.Runtime)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("server", cluster.Server)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("labels", cluster.Metadata.Labels)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("connection_status", cluster.ConnectionStatus)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceToGitopsCluster(d *schema.ResourceData, withCredentials bool) *cfClient.GitopsCluster {
+	labels := map[string]string{}
+	for key, value := range d.Get("labels").(map[string]interface{}) {
+		labels[key] = value.(string)
+	}
+
+	cluster := &cfClient.GitopsCluster{
+		Metadata: cfClient.GitopsClusterMetadata{
+			Name:    d.Get("name").(string),
+			Runtime: d.Get("runtime").(string),
+			Labels:  labels,
+		},
+		Server: d.Get("server").(string),
+	}
+	if withCredentials {
+		bearerToken := d.Get("bearer_token").(string)
+		if bearerToken == "" {
+			bearerToken = d.Get("bearer_token_wo").(string)
+		}
+		cluster.Config = &cfClient.GitopsClusterConfig{
+			BearerToken: bearerToken,
+			Kubeconfig:  d.Get("kubeconfig").(string),
+		}
+	}
+	return cluster
+}
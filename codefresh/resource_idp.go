@@ -1,19 +1,20 @@
 package codefresh
 
 import (
-	"fmt"
+	"context"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceIdps() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceIdpRead,
-		Schema: IdpSchema(),
+		ReadContext: dataSourceIdpRead,
+		Schema:      IdpSchema(),
 	}
 }
 
-//IdpSchema -
+// IdpSchema -
 func IdpSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"_id": {
@@ -81,13 +82,13 @@ func IdpSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceIdpRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceIdpRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	idps, err := client.GetIDPs()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_id, _idOk := d.GetOk("_id")
@@ -96,7 +97,7 @@ func dataSourceIdpRead(d *schema.ResourceData, meta interface{}) error {
 	clientType, clientTypeOk := d.GetOk("client_type")
 
 	if !(_idOk || clientNameOk || displayNameOk || clientTypeOk) {
-		return fmt.Errorf("[ERROR] data.codefresh_idp - no parameters specified")
+		return diag.Errorf("[ERROR] data.codefresh_idp - no parameters specified")
 	}
 	for _, idp := range *idps {
 		if clientNameOk && clientName.(string) != idp.ClientName {
@@ -113,12 +114,12 @@ func dataSourceIdpRead(d *schema.ResourceData, meta interface{}) error {
 		}
 		err = mapDataIdpToResource(idp, d)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if d.Id() == "" {
-		return fmt.Errorf("[EROOR] Idp wasn't found")
+		return diag.Errorf("[EROOR] Idp wasn't found")
 	}
 
 	return nil
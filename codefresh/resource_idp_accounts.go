@@ -1,16 +1,19 @@
 package codefresh
 
 import (
+	"context"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIDPAccounts() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountIDPCreate,
-		Read:   resourceAccountIDPRead,
-		Update: resourceAccountIDPUpdate,
-		Delete: resourceAccountIDPDelete,
+		CreateContext: resourceAccountIDPCreate,
+		ReadContext:   resourceAccountIDPRead,
+		UpdateContext: resourceAccountIDPUpdate,
+		DeleteContext: resourceAccountIDPDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -30,9 +33,9 @@ func resourceIDPAccounts() *schema.Resource {
 	}
 }
 
-func resourceAccountIDPCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountIDPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	accountIds := convertStringArr(d.Get("account_ids").(*schema.Set).List())
 
@@ -40,7 +43,7 @@ func resourceAccountIDPCreate(d *schema.ResourceData, meta interface{}) error {
 
 	idp, err := client.GetIdpByID(idpID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for _, accountID := range accountIds {
@@ -52,9 +55,9 @@ func resourceAccountIDPCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceAccountIDPRead(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountIDPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	idpID := d.Id()
 	if idpID == "" {
@@ -64,37 +67,37 @@ func resourceAccountIDPRead(d *schema.ResourceData, meta interface{}) error {
 
 	idp, err := client.GetIdpByID(idpID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("idp_id", idp.ID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("account_ids", idp.Accounts)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceAccountIDPDelete(_ *schema.ResourceData, _ interface{}) error {
+func resourceAccountIDPDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	// todo
 	// warning message
 	return nil
 }
 
-func resourceAccountIDPUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceAccountIDPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	idpID := d.Id()
 
 	idp, err := client.GetIdpByID(idpID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	existingAccounts := idp.Accounts
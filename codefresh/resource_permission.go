@@ -1,21 +1,30 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// defaultPermissionTags are sent to the API when the permission doesn't define any tag
+var defaultPermissionTags = []string{"*", "untagged"}
+
 func resourcePermission() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePermissionCreate,
-		Read:   resourcePermissionRead,
-		Update: resourcePermissionUpdate,
-		Delete: resourcePermissionDelete,
+		CreateContext: resourcePermissionCreate,
+		ReadContext:   resourcePermissionRead,
+		UpdateContext: resourcePermissionUpdate,
+		DeleteContext: resourcePermissionDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importPermissionState,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return checkUnknownTags(d, meta, fmt.Sprintf("the permission of team %s", d.Get("team")))
 		},
 		Schema: map[string]*schema.Schema{
 			"_id": {
@@ -32,9 +41,12 @@ func resourcePermission() *schema.Resource {
 				Required: true,
 				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 					v := val.(string)
-					if v != "cluster" && v != "pipeline" {
-						errs = append(errs, fmt.Errorf("%q must be between \"pipeline\" or \"cluster\", got: %s", key, v))
+					for _, resource := range cfClient.PermissionResources {
+						if v == resource {
+							return
+						}
 					}
+					errs = append(errs, fmt.Errorf("%q must be one of %s, got: %s", key, strings.Join(cfClient.PermissionResources, ","), v))
 					return
 				},
 			},
@@ -60,27 +72,27 @@ func resourcePermission() *schema.Resource {
 	}
 }
 
-func resourcePermissionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	permission := *mapResourceToPermission(d)
 
 	newPermission, err := client.CreatePermission(&permission)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if newPermission == nil {
-		return fmt.Errorf("resourcePermissionCreate - failed to create permission, empty responce")
+		return diag.Errorf("resourcePermissionCreate - failed to create permission, empty responce")
 	}
 
 	d.SetId(newPermission.ID)
 
-	return resourcePermissionRead(d, meta)
+	return readCreatedEntity(ctx, d, meta, resourcePermissionRead, resourcePermissionDelete)
 }
 
-func resourcePermissionRead(d *schema.ResourceData, meta interface{}) error {
+func resourcePermissionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	permissionID := d.Id()
 	if permissionID == "" {
@@ -90,45 +102,44 @@ func resourcePermissionRead(d *schema.ResourceData, meta interface{}) error {
 
 	permission, err := client.GetPermissionByID(permissionID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapPermissionToResource(permission, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePermissionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	permission := *mapResourceToPermission(d)
 	permission.ID = ""
 	resp, err := client.CreatePermission(&permission)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	deleteErr := resourcePermissionDelete(d, meta)
+	deleteErr := resourcePermissionDelete(ctx, d, meta)
 	if deleteErr != nil {
 		log.Printf("[WARN] failed to delete permission %v: %v", permission, deleteErr)
 	}
 	d.SetId(resp.ID)
 
-	return resourcePermissionRead(d, meta)
+	return resourcePermissionRead(ctx, d, meta)
 }
 
-func resourcePermissionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourcePermissionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	err := client.DeletePermission(d.Id())
-	if err != nil {
+	return diag.FromErr(ignoreDeletedEntity("permission", d.Id(), err, func() error {
+		_, err := client.GetPermissionByID(d.Id())
 		return err
-	}
-
-	return nil
+	}))
 }
 
 func mapPermissionToResource(permission *cfClient.Permission, d *schema.ResourceData) error {
@@ -153,7 +164,8 @@ func mapPermissionToResource(permission *cfClient.Permission, d *schema.Resource
 		return err
 	}
 
-	err = d.Set("tags", permission.Tags)
+	stateTags := convertStringArr(d.Get("tags").(*schema.Set).List())
+	err = d.Set("tags", repairPermissionTags(permission.Tags, stateTags))
 	if err != nil {
 		return err
 	}
@@ -166,9 +178,9 @@ func mapResourceToPermission(d *schema.ResourceData) *cfClient.Permission {
 	tagsI := d.Get("tags").(*schema.Set).List()
 	var tags []string
 	if len(tagsI) > 0 {
-		tags = convertStringArr(tagsI)
+		tags = normalizeTags(convertStringArr(tagsI))
 	} else {
-		tags = []string{"*", "untagged"}
+		tags = defaultPermissionTags
 	}
 	permission := &cfClient.Permission{
 		ID:       d.Id(),
@@ -180,3 +192,33 @@ func mapResourceToPermission(d *schema.ResourceData) *cfClient.Permission {
 
 	return permission
 }
+
+// repairPermissionTags normalizes the tags returned by the API so that they match the configuration:
+// the default tags are hidden when no tags were set and the spelling of the state is kept
+// for tags that the API changed only by case
+func repairPermissionTags(apiTags []string, stateTags []string) []string {
+	tags := normalizeTags(apiTags)
+
+	if len(stateTags) == 0 && len(tags) == len(defaultPermissionTags) {
+		isDefault := true
+		for _, tag := range defaultPermissionTags {
+			if !cfClient.FindInSlice(tags, tag) {
+				isDefault = false
+			}
+		}
+		if isDefault {
+			return []string{}
+		}
+	}
+
+	for i, tag := range tags {
+		for _, stateTag := range stateTags {
+			if strings.EqualFold(tag, stateTag) {
+				tags[i] = stateTag
+				break
+			}
+		}
+	}
+
+	return normalizeTags(tags)
+}
@@ -0,0 +1,63 @@
+package codefresh
+
+import (
+	"reflect"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+)
+
+// Unit Testing
+func TestRepairPermissionTags(t *testing.T) {
+	cases := []struct {
+		name      string
+		apiTags   []string
+		stateTags []string
+		expected  []string
+	}{
+		{"default tags without state", []string{"untagged", "*"}, []string{}, []string{}},
+		{"default tags with state", []string{"*", "untagged"}, []string{"*", "untagged"}, []string{"*", "untagged"}},
+		{"duplicates and spaces", []string{"frontend", " frontend", "backend ", ""}, []string{"frontend", "backend"}, []string{"frontend", "backend"}},
+		{"case changed by the API", []string{"frontend"}, []string{"Frontend"}, []string{"Frontend"}},
+		{"tag added outside terraform", []string{"frontend", "backend"}, []string{"frontend"}, []string{"frontend", "backend"}},
+	}
+
+	for _, c := range cases {
+		tags := repairPermissionTags(c.apiTags, c.stateTags)
+		if !reflect.DeepEqual(tags, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, tags)
+		}
+	}
+}
+
+func TestPermissionResourceValidation(t *testing.T) {
+	validate := resourcePermission().Schema["resource"].ValidateFunc
+
+	for _, resource := range []string{"pipeline", "cluster", "runtime-environment"} {
+		if _, errs := validate(resource, "resource"); len(errs) > 0 {
+			t.Errorf("%s: unexpected errors %v", resource, errs)
+		}
+	}
+	if _, errs := validate("project", "resource"); len(errs) == 0 {
+		t.Errorf("project: expected an error")
+	}
+}
+
+func TestAggregateTeamPermissions(t *testing.T) {
+	permissions := []cfClient.Permission{
+		{ID: "3", Resource: "pipeline", Action: "run", Tags: []string{"production"}},
+		{ID: "1", Resource: "pipeline", Action: "run", Tags: []string{"*", "production"}},
+		{ID: "2", Resource: "cluster", Action: "read", Tags: []string{"untagged"}},
+		{ID: "4", Resource: "pipeline", Action: "create"},
+	}
+
+	expected := []map[string]interface{}{
+		{"resource": "cluster", "action": "read", "tags": []string{"untagged"}, "permission_ids": []string{"2"}},
+		{"resource": "pipeline", "action": "create", "tags": []string{}, "permission_ids": []string{"4"}},
+		{"resource": "pipeline", "action": "run", "tags": []string{"*", "production"}, "permission_ids": []string{"1", "3"}},
+	}
+
+	if res := aggregateTeamPermissions(permissions); !reflect.DeepEqual(res, expected) {
+		t.Errorf("Expected %v, got %v", expected, res)
+	}
+}
@@ -1,37 +1,48 @@
 package codefresh
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	ghodss "github.com/ghodss/yaml"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/iancoleman/orderedmap"
 	"gopkg.in/yaml.v2"
 )
 
 var terminationPolicyOnCreateBranchAttributes = []string{"branchName", "ignoreTrigger", "ignoreBranch"}
 
 func resourcePipeline() *schema.Resource {
-	return &schema.Resource{
-		Create: resourcePipelineCreate,
-		Read:   resourcePipelineRead,
-		Update: resourcePipelineUpdate,
-		Delete: resourcePipelineDelete,
+	return withDeprecations(&schema.Resource{
+		CreateContext: resourcePipelineCreate,
+		ReadContext:   resourcePipelineRead,
+		UpdateContext: resourcePipelineUpdate,
+		DeleteContext: resourcePipelineDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importPipelineState,
 		},
+		CustomizeDiff: resourcePipelineCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"original_yaml_string": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"spec.0.spec_template"},
 			},
 			"project_id": {
 				Type:     schema.TypeString,
@@ -42,10 +53,64 @@ func resourcePipeline() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"is_template": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"template_pipeline_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"effective_concurrency": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"canonical_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"canonical_json_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"adopt_existing": adoptExistingSchema(),
+			"strict_spec": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"manage_triggers_externally": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"triggers_authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"check_revision": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"revision": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"builds_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -53,6 +118,28 @@ func resourcePipeline() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"exclude_default_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags_all": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"spec": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -80,8 +167,9 @@ func resourcePipeline() *schema.Resource {
 							Default:  0, // zero is unlimited
 						},
 						"spec_template": {
-							Type:     schema.TypeList,
-							Optional: true,
+							Type:          schema.TypeList,
+							Optional:      true,
+							ConflictsWith: []string{"original_yaml_string"},
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"location": {
@@ -109,135 +197,90 @@ func resourcePipeline() *schema.Resource {
 								},
 							},
 						},
+						"steps_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ConflictsWith:    []string{"original_yaml_string", "spec.0.spec_template"},
+							ValidateFunc:     validateStepsJSON,
+							DiffSuppressFunc: suppressEquivalentStepsJSONDiffs,
+							StateFunc: func(v interface{}) string {
+								steps, _ := normalizeStepsJSON(v.(string))
+								return steps
+							},
+						},
+						"stages_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ConflictsWith:    []string{"original_yaml_string", "spec.0.spec_template"},
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							StateFunc: func(v interface{}) string {
+								stages, _ := structure.NormalizeJsonString(v)
+								return stages
+							},
+						},
 						"variables": {
-							Type:     schema.TypeMap,
-							Optional: true,
+							Type:          schema.TypeMap,
+							Optional:      true,
+							ConflictsWith: []string{"spec.0.variable"},
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
 						},
-						"trigger": {
-							Type:     schema.TypeList,
+						"protect_variables": {
+							Type:     schema.TypeBool,
 							Optional: true,
+							Default:  false,
+						},
+						"execution_context_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"variable": {
+							Type:          schema.TypeList,
+							Optional:      true,
+							ConflictsWith: []string{"spec.0.variables"},
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
-									"name": {
+									"key": {
 										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"description": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "git",
-									},
-									"repo": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"branch_regex": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										Default:      "/.*/gi",
-										ValidateFunc: stringIsValidRe2RegExp,
-									},
-									"branch_regex_input": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										Default:      "regex",
-										ValidateFunc: validation.StringInSlice([]string{"multiselect-exclude", "multiselect", "regex"}, false),
-									},
-									"pull_request_target_branch_regex": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										ValidateFunc: stringIsValidRe2RegExp,
-									},
-									"comment_regex": {
-										Type:         schema.TypeString,
-										Optional:     true,
-										Default:      "/.*/gi",
-										ValidateFunc: stringIsValidRe2RegExp,
-									},
-									"modified_files_glob": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "",
-									},
-									"events": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
+										Required: true,
 									},
-									"provider": {
+									"value": {
 										Type:     schema.TypeString,
 										Optional: true,
-										Default:  "github",
 									},
-									"disabled": {
+									"encrypted": {
 										Type:     schema.TypeBool,
 										Optional: true,
 										Default:  false,
 									},
-									"pull_request_allow_fork_events": {
-										Type:     schema.TypeBool,
-										Optional: true,
-										Default:  false,
-									},
-									"commit_status_title": {
+									"description": {
 										Type:     schema.TypeString,
 										Optional: true,
 									},
-									"context": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "github",
-									},
-									"contexts": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"runtime_environment": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"name": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"memory": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"cpu": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"dind_storage": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-											},
-										},
-									},
-									"variables": {
-										Type:     schema.TypeMap,
+									"required": {
+										Type:     schema.TypeBool,
 										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
+										Default:  false,
 									},
 								},
 							},
 						},
+						"trigger": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: pipelineTriggerSchema(),
+							},
+						},
+						"cron_trigger": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: pipelineCronTriggerSchema(),
+							},
+						},
 						"contexts": {
 							Type:     schema.TypeList,
 							Optional: true,
@@ -327,28 +370,394 @@ func resourcePipeline() *schema.Resource {
 				},
 			},
 		},
+	}, deprecation{
+		attribute:    "spec.0.trigger.*.provider",
+		legacyValues: renamedTriggerProviders,
+		migration:    renamedTriggerProvidersMigration,
+	})
+}
+
+func resourcePipelineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// the values known only at apply time aren't checked by ConflictsWith
+	if _, ok := d.GetOk("spec.0.spec_template"); ok && d.Get("original_yaml_string").(string) != "" {
+		return fmt.Errorf("original_yaml_string and spec.0.spec_template are mutually exclusive: " +
+			"with spec_template the pipeline YAML is loaded from git when the build starts and original_yaml_string would be ignored. " +
+			"Remove original_yaml_string to use the YAML from git, or remove spec_template to use the inline YAML")
+	}
+	if triggers, ok := d.GetOk("spec.0.trigger"); ok && d.Get("manage_triggers_externally").(bool) && len(triggers.([]interface{})) > 0 {
+		return fmt.Errorf("spec.0.trigger can't be set when manage_triggers_externally is enabled, use codefresh_pipeline_trigger instead")
+	}
+	if err := validateUniqueTriggerNames(d.Get("spec.0.trigger").([]interface{})); err != nil {
+		return err
+	}
+	for i := range d.Get("spec.0.trigger").([]interface{}) {
+		prefix := fmt.Sprintf("spec.0.trigger.%d.", i)
+		err := validateTrigger(d.Id(), func(key string) interface{} { return d.Get(prefix + key) })
+		if err != nil {
+			return fmt.Errorf("spec.0.trigger.%d: %v", i, err)
+		}
+	}
+	if err := validateCronTriggers(d.Get("name").(string), d.Get("spec.0.cron_trigger").([]interface{})); err != nil {
+		return err
+	}
+	if err := customizeEffectiveConcurrency(d, meta); err != nil {
+		return err
+	}
+	if err := customizePipelineCanonicalJSON(d); err != nil {
+		return err
+	}
+	if err := customizePipelineStepAllowList(d, meta); err != nil {
+		return err
+	}
+	if err := customizeTagsAll(d, meta); err != nil {
+		return err
+	}
+	return checkUnknownTags(d, meta, fmt.Sprintf("pipeline %s", d.Get("name")))
+}
+
+// renamedTriggerProviders the git providers of the triggers renamed by the platform
+var renamedTriggerProviders = renamedValues{
+	"stash": "bitbucket-server",
+}
+
+// renamedTriggerProvidersMigration the migration of the renamed git providers of the triggers
+const renamedTriggerProvidersMigration = "Use bitbucket-server instead of stash."
+
+// codefreshTriggerEvents the events of the source pipeline's builds which run the pipeline
+// with a trigger of type codefresh
+var codefreshTriggerEvents = []string{"build.success", "build.failure", "build.terminated"}
+
+// validateTrigger checks the attributes of the trigger depending on its type, the pipeline triggered by
+// a codefresh trigger is run on the completion of the builds of the source pipeline instead of on git events
+func validateTrigger(pipelineID string, get func(key string) interface{}) error {
+	encryptedVariables, _ := get("encrypted_variables").(map[string]interface{})
+	writeOnlyVariables, _ := get("encrypted_variables_wo").(map[string]interface{})
+	for key := range writeOnlyVariables {
+		if _, ok := encryptedVariables[key]; ok {
+			return fmt.Errorf("the variable %s can't be set in both encrypted_variables and encrypted_variables_wo", key)
+		}
+	}
+
+	sourcePipelineID := get("source_pipeline_id").(string)
+	if get("type").(string) != "codefresh" {
+		if sourcePipelineID != "" {
+			return fmt.Errorf("source_pipeline_id can only be set for the triggers of type codefresh")
+		}
+		return nil
+	}
+
+	if sourcePipelineID == "" {
+		return fmt.Errorf("source_pipeline_id is required for the triggers of type codefresh")
+	}
+	if pipelineID != "" && sourcePipelineID == pipelineID {
+		return fmt.Errorf("the pipeline can't be triggered by its own builds")
+	}
+	if get("repo").(string) != "" {
+		return fmt.Errorf("repo can't be set for the triggers of type codefresh, they aren't run on git events")
+	}
+	for _, event := range get("events").([]interface{}) {
+		if !cfClient.FindInSlice(codefreshTriggerEvents, event.(string)) {
+			return fmt.Errorf("the event %s isn't supported by the triggers of type codefresh, expected one of %v", event, codefreshTriggerEvents)
+		}
+	}
+	return nil
+}
+
+// triggerNameRegex the characters allowed in the trigger names, the name is part of the ID of codefresh_pipeline_trigger
+var triggerNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ._-]*$`)
+
+// validateTriggerName checks the characters of the trigger name at plan time instead of letting the API reject it
+var validateTriggerName = validation.StringMatch(triggerNameRegex,
+	"the trigger name must start with a letter or a digit and contain only letters, digits, spaces, dots, dashes and underscores")
+
+// validateUniqueTriggerNames checks that the names of the triggers of the pipeline are unique, the API rejects or merges
+// the triggers with the same name. The names unknown at plan time are ignored
+func validateUniqueTriggerNames(triggers []interface{}) error {
+	indexes := map[string]int{}
+	for i, trigger := range triggers {
+		if trigger == nil {
+			continue
+		}
+		name := trigger.(map[string]interface{})["name"].(string)
+		if name == "" {
+			continue
+		}
+		if first, ok := indexes[name]; ok {
+			return fmt.Errorf("spec.0.trigger.%d: the trigger name %s is already used by spec.0.trigger.%d, the trigger names of a pipeline must be unique", i, name, first)
+		}
+		indexes[name] = i
+	}
+	return nil
+}
+
+// pipelineTriggerSchema the schema of a trigger, shared by the inline trigger blocks of the pipeline
+// and the codefresh_pipeline_trigger resource
+func pipelineTriggerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateTriggerName,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "git",
+		},
+		"repo": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"source_pipeline_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"branch_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "/.*/gi",
+			ValidateFunc: stringIsValidRe2RegExp,
+			StateFunc: func(v interface{}) string {
+				return normalizeBranchRegex(v.(string))
+			},
+		},
+		"branch_regex_input": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "regex",
+			ValidateFunc: validation.StringInSlice([]string{"multiselect-exclude", "multiselect", "regex"}, false),
+		},
+		"pull_request_target_branch_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: stringIsValidRe2RegExp,
+		},
+		"comment_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "/.*/gi",
+			ValidateFunc: stringIsValidRe2RegExp,
+		},
+		"modified_files_glob": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "",
+		},
+		"events": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"provider": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "github",
+			ValidateFunc:     renamedTriggerProviders.validate,
+			DiffSuppressFunc: renamedTriggerProviders.suppressDiff,
+		},
+		"disabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"pull_request_allow_fork_events": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"pull_request_head_repo_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: stringIsValidRe2RegExp,
+		},
+		"pull_request_fork_expose_secrets": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"commit_status_title": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"commit_message_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: stringIsValidRe2RegExp,
+		},
+		"skip_ci_check": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"context": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "github",
+		},
+		"contexts": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"options": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"no_cache": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"no_cf_cache": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"reset_volume": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"enable_notifications": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		"annotations": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"runtime_environment": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"memory": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"cpu": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"dind_storage": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"variables": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"encrypted_variables": {
+			Type:      schema.TypeMap,
+			Optional:  true,
+			Sensitive: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		// the state only has the keys of the write-only variables, see keepTriggerWriteOnlyVariables
+		"encrypted_variables_wo": {
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Sensitive:        true,
+			DiffSuppressFunc: suppressListWriteOnlyDiff("encrypted_variables_wo", "encrypted_variables_wo_version", "name"),
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+		"encrypted_variables_wo_version": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
 	}
 }
 
-func resourcePipelineCreate(d *schema.ResourceData, meta interface{}) error {
+func resourcePipelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	pipeline := *mapResourceToPipeline(d)
+	pipeline.Metadata.Labels.Tags = getTagsWithDefaults(d, meta)
+	applyPipelineSpecDefaults(&pipeline.Spec, getProviderSettings(meta).pipelineSpecDefaults)
+	cronTriggers, err := mapResourceToCronTriggers(pipeline.Metadata.Name, d.Get("spec.0.cron_trigger").([]interface{}), time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pipeline.Spec.CronTriggers = cronTriggers
+
+	lookup := func() (string, error) {
+		pipelines, err := client.GetPipelines(&cfClient.PipelinesFilter{Name: pipeline.Metadata.Name})
+		if err != nil {
+			return "", err
+		}
+		for _, existingPipeline := range pipelines {
+			if existingPipeline.Metadata.Name == pipeline.Metadata.Name {
+				return existingPipeline.Metadata.ID, nil
+			}
+		}
+		return "", nil
+	}
+	adoptExisting := getAdoptExisting(d, meta)
+	nameWasFree := adoptExisting || isEntityNameFree(lookup)
 
 	resp, err := client.CreatePipeline(&pipeline)
+	if cfClient.IsConflictError(err) && adoptExisting {
+		existingPipeline, err := client.GetPipelineByName(pipeline.Metadata.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] Adopting the existing pipeline %s", pipeline.Metadata.Name)
+		d.SetId(existingPipeline.Metadata.ID)
+		// the adopted pipeline was never read by terraform, its current revision is the one check_revision expects
+		if err := d.Set("revision", existingPipeline.Metadata.Revision); err != nil {
+			return diag.FromErr(err)
+		}
+		return resourcePipelineUpdate(ctx, d, meta)
+	}
 	if err != nil {
-		return err
+		id, err := reconcileCreatedEntity("pipeline", pipeline.Metadata.Name, nameWasFree, err, lookup)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp = &cfClient.Pipeline{Metadata: cfClient.Metadata{ID: id}}
 	}
 
 	d.SetId(resp.Metadata.ID)
 
-	return resourcePipelineRead(d, meta)
+	return readCreatedEntity(ctx, d, meta, resourcePipelineRead, resourcePipelineDelete)
 }
 
-func resourcePipelineRead(d *schema.ResourceData, meta interface{}) error {
+func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	pipelineID := d.Id()
 
@@ -359,42 +768,125 @@ func resourcePipelineRead(d *schema.ResourceData, meta interface{}) error {
 
 	pipeline, err := client.GetPipeline(pipelineID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	// the definition deployed, including the provider's defaults
+	err = setPipelineCanonicalJSON(*pipeline, d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
+	removePipelineSpecDefaults(&pipeline.Spec, getProviderSettings(meta).pipelineSpecDefaults, d)
+
 	err = mapPipelineToResource(*pipeline, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	err = setTagsWithoutDefaults(pipeline.Metadata.Labels.Tags, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("effective_concurrency", getEffectiveConcurrency(meta, pipeline.Spec.Concurrency))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("strict_spec").(bool) {
+		unknownFields, err := client.GetPipelineUnknownSpecFields(pipelineID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(unknownFields) > 0 {
+			return attributeErrorf(cty.GetAttrPath("strict_spec"), "strict_spec is enabled and the pipeline %s has settings which aren't managed by Terraform: spec.%s",
+				pipeline.Metadata.Name, strings.Join(unknownFields, ", spec."))
+		}
+	}
+
+	err = d.Set("url", fmt.Sprintf("%s/pipelines/edit/workflow?id=%s", client.GetUIURL(), url.QueryEscape(pipelineID)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("builds_url", fmt.Sprintf("%s/builds2?filter=pipeline:%s", client.GetUIURL(), url.QueryEscape(pipelineID)))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePipelineUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourcePipelineUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	pipeline := *mapResourceToPipeline(d)
 	pipeline.Metadata.ID = d.Id()
+	pipeline.Metadata.Labels.Tags = getTagsWithDefaults(d, meta)
+	applyPipelineSpecDefaults(&pipeline.Spec, getProviderSettings(meta).pipelineSpecDefaults)
+	cronTriggers, err := mapResourceToCronTriggers(pipeline.Metadata.Name, d.Get("spec.0.cron_trigger").([]interface{}), time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pipeline.Spec.CronTriggers = cronTriggers
 
-	_, err := client.UpdatePipeline(&pipeline)
+	unlock := lockPipeline(d.Id())
+	defer unlock()
+
+	manageTriggersExternally := d.Get("manage_triggers_externally").(bool)
+	triggersAuthoritative := d.Get("triggers_authoritative").(bool)
+	checkRevision := d.Get("check_revision").(bool)
+	keepWriteOnlyVariables := false
+	for idx := range pipeline.Spec.Triggers {
+		keepWriteOnlyVariables = keepWriteOnlyVariables || len(keptTriggerWriteOnlyVariables(d, fmt.Sprintf("spec.0.trigger.%v.", idx))) > 0
+	}
+	if manageTriggersExternally || !triggersAuthoritative || checkRevision || keepWriteOnlyVariables {
+		// the current triggers are sent back, with the values of their encrypted variables
+		currentPipeline, err := client.GetPipelineWithDecrypt(d.Id(), true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if checkRevision && currentPipeline.Metadata.Revision != d.Get("revision").(int) {
+			// someone else updated the pipeline since it was read by terraform. The API has no conditional update,
+			// so the check is best-effort: an update between this read and the PUT isn't detected
+			return diag.Errorf("the pipeline %s was modified outside of this run (revision %d at %s, expected revision %d), refresh the state and review the plan again",
+				currentPipeline.Metadata.Name, currentPipeline.Metadata.Revision, currentPipeline.Metadata.UpdatedAt, d.Get("revision").(int))
+		}
+		if keepWriteOnlyVariables {
+			for idx := range pipeline.Spec.Triggers {
+				keepTriggerWriteOnlyVariables(d, fmt.Sprintf("spec.0.trigger.%v.", idx), &pipeline.Spec.Triggers[idx], currentPipeline.Spec.Triggers)
+			}
+		}
+		if manageTriggersExternally {
+			// keep the triggers managed by the codefresh_pipeline_trigger resources
+			pipeline.Spec.Triggers = currentPipeline.Spec.Triggers
+		} else if !triggersAuthoritative {
+			// keep the triggers added outside of terraform, the triggers removed from the configuration are deleted.
+			// They are sent back as read with decrypt, with the values of their encrypted variables
+			previousTriggers, _ := d.GetChange("spec.0.trigger")
+			pipeline.Spec.Triggers = mergeUnmanagedTriggers(pipeline.Spec.Triggers, currentPipeline.Spec.Triggers, previousTriggers.([]interface{}))
+		}
+	}
+
+	_, err = client.UpdatePipeline(&pipeline)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePipelineRead(d, meta)
+	return resourcePipelineRead(ctx, d, meta)
 }
 
-func resourcePipelineDelete(d *schema.ResourceData, meta interface{}) error {
+func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	err := client.DeletePipeline(d.Id())
-	if err != nil {
+	return diag.FromErr(ignoreDeletedEntity("pipeline", d.Id(), err, func() error {
+		_, err := client.GetPipeline(d.Id())
 		return err
-	}
-
-	return nil
+	}))
 }
 
 func mapPipelineToResource(pipeline cfClient.Pipeline, d *schema.ResourceData) error {
@@ -409,6 +901,11 @@ func mapPipelineToResource(pipeline cfClient.Pipeline, d *schema.ResourceData) e
 		return err
 	}
 
+	err = d.Set("updated_at", pipeline.Metadata.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
 	err = d.Set("project_id", pipeline.Metadata.ProjectId)
 	if err != nil {
 		return err
@@ -419,7 +916,41 @@ func mapPipelineToResource(pipeline cfClient.Pipeline, d *schema.ResourceData) e
 		return err
 	}
 
-	err = d.Set("spec", flattenSpec(pipeline.Spec))
+	template := pipeline.Metadata.Template
+	if template == nil {
+		template = &cfClient.PipelineTemplate{}
+	}
+	err = d.Set("is_template", template.IsTemplate)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("template_pipeline_id", template.GeneratedFrom)
+	if err != nil {
+		return err
+	}
+
+	spec := pipeline.Spec
+	if d.Get("manage_triggers_externally").(bool) {
+		spec.Triggers = nil
+	} else if !d.Get("triggers_authoritative").(bool) {
+		spec.Triggers = filterManagedTriggers(spec.Triggers, d.Get("spec.0.trigger").([]interface{}))
+	}
+
+	flattenedSpec := flattenSpec(spec, d.Get("spec.0.variable").([]interface{}), d.Get("spec.0.trigger").([]interface{}))
+	// the steps and stages are only read back when they are configured as JSON, not when they come from the YAML
+	if m, ok := flattenedSpec[0].(map[string]interface{}); ok {
+		if len(spec.CronTriggers) > 0 {
+			m["cron_trigger"] = flattenCronTriggers(pipeline.Metadata.Name, spec.CronTriggers, d.Get("spec.0.cron_trigger").([]interface{}), time.Now())
+		}
+		if _, ok := d.GetOk("spec.0.steps_json"); ok && spec.Steps != nil {
+			m["steps_json"], _ = normalizeStepsJSON(spec.Steps.Steps)
+		}
+		if _, ok := d.GetOk("spec.0.stages_json"); ok && spec.Stages != nil {
+			m["stages_json"], _ = structure.NormalizeJsonString(spec.Stages.Stages)
+		}
+	}
+	err = d.Set("spec", flattenedSpec)
 	if err != nil {
 		return err
 	}
@@ -429,6 +960,11 @@ func mapPipelineToResource(pipeline cfClient.Pipeline, d *schema.ResourceData) e
 		return err
 	}
 
+	err = d.Set("scopes", pipeline.Metadata.Scopes)
+	if err != nil {
+		return err
+	}
+
 	err = d.Set("original_yaml_string", pipeline.Metadata.OriginalYamlString)
 	if err != nil {
 		return err
@@ -437,13 +973,55 @@ func mapPipelineToResource(pipeline cfClient.Pipeline, d *schema.ResourceData) e
 	return nil
 }
 
-func flattenSpec(spec cfClient.Spec) []interface{} {
+// getTriggerNames returns the names of the trigger blocks
+func getTriggerNames(triggers []interface{}) []string {
+	var names []string
+	for _, trigger := range triggers {
+		if t, ok := trigger.(map[string]interface{}); ok {
+			names = append(names, t["name"].(string))
+		}
+	}
+	return names
+}
+
+// filterManagedTriggers returns the triggers of the pipeline managed by terraform, i.e. the triggers in the state,
+// so that the triggers added in the UI don't show up as a diff when the triggers aren't authoritative
+func filterManagedTriggers(triggers []cfClient.Trigger, stateTriggers []interface{}) []cfClient.Trigger {
+	managedNames := getTriggerNames(stateTriggers)
+	var res []cfClient.Trigger
+	for _, trigger := range triggers {
+		if cfClient.FindInSlice(managedNames, trigger.Name) {
+			res = append(res, trigger)
+		}
+	}
+	return res
+}
+
+// mergeUnmanagedTriggers adds to the configured triggers the current triggers of the pipeline which
+// were never managed by terraform. The triggers previously managed and removed from the configuration are dropped
+func mergeUnmanagedTriggers(configuredTriggers []cfClient.Trigger, currentTriggers []cfClient.Trigger, previousTriggers []interface{}) []cfClient.Trigger {
+	managedNames := getTriggerNames(previousTriggers)
+	for _, trigger := range configuredTriggers {
+		managedNames = append(managedNames, trigger.Name)
+	}
+	res := configuredTriggers
+	for _, trigger := range currentTriggers {
+		if !cfClient.FindInSlice(managedNames, trigger.Name) {
+			res = append(res, trigger)
+		}
+	}
+	return res
+}
+
+// flattenSpec flattens the pipeline spec, the variables are flattened into variable blocks
+// when they are configured as blocks in the state or when they have metadata which the variables map can't hold
+func flattenSpec(spec cfClient.Spec, stateVariables []interface{}, stateTriggers []interface{}) []interface{} {
 
 	var res = make([]interface{}, 0)
 	m := make(map[string]interface{})
 
 	if len(spec.Triggers) > 0 {
-		m["trigger"] = flattenTriggers(spec.Triggers)
+		m["trigger"] = flattenTriggers(spec.Triggers, stateTriggers)
 	}
 
 	if spec.SpecTemplate != nil {
@@ -451,7 +1029,11 @@ func flattenSpec(spec cfClient.Spec) []interface{} {
 	}
 
 	if len(spec.Variables) != 0 {
-		m["variables"] = convertVariables(spec.Variables)
+		if len(stateVariables) > 0 || hasVariablesMetadata(spec.Variables) {
+			m["variable"] = flattenVariableBlocks(spec.Variables, stateVariables)
+		} else {
+			m["variables"] = convertVariables(spec.Variables)
+		}
 	}
 
 	if spec.RuntimeEnvironment != (cfClient.RuntimeEnvironment{}) {
@@ -485,6 +1067,9 @@ func flattenSpec(spec cfClient.Spec) []interface{} {
 
 	m["contexts"] = spec.Contexts
 
+	m["protect_variables"] = spec.ProtectVariables != nil && *spec.ProtectVariables
+	m["execution_context_id"] = spec.ExecutionContext
+
 	res = append(res, m)
 	return res
 }
@@ -493,9 +1078,13 @@ func flattenSpecTerminationPolicy(terminationPolicy []map[string]interface{}) []
 	var res []map[string]interface{}
 	attribute := make(map[string]interface{})
 	for _, policy := range terminationPolicy {
-		eventName, _ := policy["event"]
-		typeName, _ := policy["type"]
-		attributeName := convertOnCreateBranchAttributeToPipelineFormat(eventName.(string) + "_" + typeName.(string))
+		eventName, eventOK := policy["event"].(string)
+		typeName, typeOK := policy["type"].(string)
+		if !eventOK || !typeOK {
+			cfClient.WarnResponseDrift("termination policy", policy, "policy with a string event and type")
+			continue
+		}
+		attributeName := convertOnCreateBranchAttributeToPipelineFormat(eventName + "_" + typeName)
 		switch attributeName {
 		case "on_create_branch":
 			var valueList []map[string]interface{}
@@ -511,13 +1100,49 @@ func flattenSpecTerminationPolicy(terminationPolicy []map[string]interface{}) []
 				attribute[attributeName] = true
 			}
 		default:
-			log.Fatal("Unsupported event found in TerminationPolicy")
+			log.Printf("[WARN] Ignoring the unsupported termination policy %s", attributeName)
 		}
 	}
 	res = append(res, attribute)
 	return res
 }
 
+func hasVariablesMetadata(variables []cfClient.Variable) bool {
+	for _, variable := range variables {
+		if variable.Encrypted || variable.Description != "" || variable.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenVariableBlocks flattens the variables into variable blocks. The API masks the values of the
+// encrypted variables, so their values are kept from the state
+func flattenVariableBlocks(variables []cfClient.Variable, stateVariables []interface{}) []map[string]interface{} {
+	stateValues := make(map[string]string, len(stateVariables))
+	for _, stateVariable := range stateVariables {
+		if v, ok := stateVariable.(map[string]interface{}); ok {
+			stateValues[v["key"].(string)] = v["value"].(string)
+		}
+	}
+
+	res := make([]map[string]interface{}, len(variables))
+	for i, variable := range variables {
+		value := variable.Value
+		if stateValue, ok := stateValues[variable.Key]; ok && variable.Encrypted {
+			value = stateValue
+		}
+		res[i] = map[string]interface{}{
+			"key":         variable.Key,
+			"value":       value,
+			"encrypted":   variable.Encrypted,
+			"description": variable.Description,
+			"required":    variable.Required,
+		}
+	}
+	return res
+}
+
 func flattenSpecTemplate(spec cfClient.SpecTemplate) []map[string]interface{} {
 	return []map[string]interface{}{
 		{
@@ -541,35 +1166,84 @@ func flattenSpecRuntimeEnvironment(spec cfClient.RuntimeEnvironment) []map[strin
 	}
 }
 
-func flattenTriggers(triggers []cfClient.Trigger) []map[string]interface{} {
+func flattenTriggers(triggers []cfClient.Trigger, stateTriggers []interface{}) []map[string]interface{} {
+	stateEncryptedVariables := make(map[string]map[string]interface{}, len(stateTriggers))
+	stateWriteOnlyVariables := make(map[string]map[string]interface{}, len(stateTriggers))
+	for _, stateTrigger := range stateTriggers {
+		if t, ok := stateTrigger.(map[string]interface{}); ok {
+			stateEncryptedVariables[t["name"].(string)], _ = t["encrypted_variables"].(map[string]interface{})
+			stateWriteOnlyVariables[t["name"].(string)], _ = t["encrypted_variables_wo"].(map[string]interface{})
+		}
+	}
+
 	var res = make([]map[string]interface{}, len(triggers))
 	for i, trigger := range triggers {
-		m := make(map[string]interface{})
-		m["name"] = trigger.Name
-		m["description"] = trigger.Description
-		m["context"] = trigger.Context
-		m["contexts"] = trigger.Contexts
-		m["repo"] = trigger.Repo
-		m["branch_regex"] = trigger.BranchRegex
-		m["branch_regex_input"] = trigger.BranchRegexInput
-		m["pull_request_target_branch_regex"] = trigger.PullRequestTargetBranchRegex
-		m["comment_regex"] = trigger.CommentRegex
-		m["modified_files_glob"] = trigger.ModifiedFilesGlob
-		m["disabled"] = trigger.Disabled
-		m["pull_request_allow_fork_events"] = trigger.PullRequestAllowForkEvents
-		m["commit_status_title"] = trigger.CommitStatusTitle
-		m["provider"] = trigger.Provider
-		m["type"] = trigger.Type
-		m["events"] = trigger.Events
-		m["variables"] = convertVariables(trigger.Variables)
-		if trigger.RuntimeEnvironment != nil {
-			m["runtime_environment"] = flattenSpecRuntimeEnvironment(*trigger.RuntimeEnvironment)
-		}
-		res[i] = m
+		res[i] = flattenTrigger(trigger, stateEncryptedVariables[trigger.Name], stateWriteOnlyVariables[trigger.Name])
 	}
 	return res
 }
 
+// flattenTrigger flattens the trigger. The API masks the values of the encrypted variables,
+// so their values are kept from stateEncryptedVariables. The variables of stateWriteOnlyVariables
+// are flattened without their values into encrypted_variables_wo
+func flattenTrigger(trigger cfClient.Trigger, stateEncryptedVariables, stateWriteOnlyVariables map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	m["name"] = trigger.Name
+	m["description"] = trigger.Description
+	m["context"] = trigger.Context
+	m["contexts"] = trigger.Contexts
+	m["repo"] = trigger.Repo
+	m["source_pipeline_id"] = trigger.SourcePipelineID
+	m["branch_regex"] = normalizeBranchRegex(trigger.BranchRegex)
+	m["branch_regex_input"] = trigger.BranchRegexInput
+	m["pull_request_target_branch_regex"] = trigger.PullRequestTargetBranchRegex
+	m["comment_regex"] = trigger.CommentRegex
+	m["modified_files_glob"] = trigger.ModifiedFilesGlob
+	m["disabled"] = trigger.Disabled
+	m["pull_request_allow_fork_events"] = trigger.PullRequestAllowForkEvents
+	m["pull_request_head_repo_regex"] = trigger.PullRequestHeadRepoRegex
+	m["pull_request_fork_expose_secrets"] = trigger.PullRequestForkExposeSecrets
+	m["commit_status_title"] = trigger.CommitStatusTitle
+	m["commit_message_regex"] = trigger.CommitMessageRegex
+	m["skip_ci_check"] = trigger.SkipCICheck
+	m["provider"] = trigger.Provider
+	m["type"] = trigger.Type
+	m["events"] = trigger.Events
+	variables := map[string]string{}
+	encryptedVariables := map[string]string{}
+	writeOnlyVariables := map[string]string{}
+	for _, variable := range trigger.Variables {
+		if !variable.Encrypted {
+			variables[variable.Key] = variable.Value
+			continue
+		}
+		if _, ok := stateWriteOnlyVariables[variable.Key]; ok {
+			writeOnlyVariables[variable.Key] = ""
+			continue
+		}
+		encryptedVariables[variable.Key] = variable.Value
+		if stateValue, ok := stateEncryptedVariables[variable.Key].(string); ok {
+			encryptedVariables[variable.Key] = stateValue
+		}
+	}
+	m["variables"] = variables
+	m["encrypted_variables"] = encryptedVariables
+	m["encrypted_variables_wo"] = writeOnlyVariables
+	if trigger.RuntimeEnvironment != nil {
+		m["runtime_environment"] = flattenSpecRuntimeEnvironment(*trigger.RuntimeEnvironment)
+	}
+	if trigger.Options != nil {
+		m["options"] = []map[string]interface{}{{
+			"no_cache":             trigger.Options.NoCache,
+			"no_cf_cache":          trigger.Options.NoCfCache,
+			"reset_volume":         trigger.Options.ResetVolume,
+			"enable_notifications": trigger.Options.EnableNotifications,
+		}}
+	}
+	m["annotations"] = trigger.Annotations
+	return m
+}
+
 func mapResourceToPipeline(d *schema.ResourceData) *cfClient.Pipeline {
 
 	tags := d.Get("tags").(*schema.Set).List()
@@ -585,10 +1259,16 @@ func mapResourceToPipeline(d *schema.ResourceData) *cfClient.Pipeline {
 			Revision:  d.Get("revision").(int),
 			ProjectId: d.Get("project_id").(string),
 			IsPublic:  d.Get("is_public").(bool),
+			// the reference to the template is sent back, so that the update doesn't unlink the pipeline from it
+			Template: &cfClient.PipelineTemplate{
+				IsTemplate:    d.Get("is_template").(bool),
+				GeneratedFrom: d.Get("template_pipeline_id").(string),
+			},
 			Labels: cfClient.Labels{
 				Tags: convertStringArr(tags),
 			},
 			OriginalYamlString: originalYamlString,
+			Scopes:             convertStringArr(d.Get("scopes").(*schema.Set).List()),
 		},
 		Spec: cfClient.Spec{
 			Priority:           d.Get("spec.0.priority").(int),
@@ -598,6 +1278,11 @@ func mapResourceToPipeline(d *schema.ResourceData) *cfClient.Pipeline {
 		},
 	}
 
+	// always sent, so that the protection can be disabled
+	protectVariables := d.Get("spec.0.protect_variables").(bool)
+	pipeline.Spec.ProtectVariables = &protectVariables
+	pipeline.Spec.ExecutionContext = d.Get("spec.0.execution_context_id").(string)
+
 	if _, ok := d.GetOk("spec.0.spec_template"); ok {
 		pipeline.Spec.SpecTemplate = &cfClient.SpecTemplate{
 			Location: d.Get("spec.0.spec_template.0.location").(string),
@@ -610,6 +1295,19 @@ func mapResourceToPipeline(d *schema.ResourceData) *cfClient.Pipeline {
 		extractSpecAttributesFromOriginalYamlString(originalYamlString, pipeline)
 	}
 
+	if stepsJSON, ok := d.GetOk("spec.0.steps_json"); ok {
+		steps, _ := normalizeStepsJSON(stepsJSON.(string))
+		pipeline.Spec.Steps = &cfClient.Steps{
+			Steps: steps,
+		}
+	}
+	if stagesJSON, ok := d.GetOk("spec.0.stages_json"); ok {
+		stages, _ := structure.NormalizeJsonString(stagesJSON.(string))
+		pipeline.Spec.Stages = &cfClient.Stages{
+			Stages: stages,
+		}
+	}
+
 	if _, ok := d.GetOk("spec.0.runtime_environment"); ok {
 		pipeline.Spec.RuntimeEnvironment = cfClient.RuntimeEnvironment{
 			Name:        d.Get("spec.0.runtime_environment.0.name").(string),
@@ -625,40 +1323,20 @@ func mapResourceToPipeline(d *schema.ResourceData) *cfClient.Pipeline {
 	variables := d.Get("spec.0.variables").(map[string]interface{})
 	pipeline.SetVariables(variables)
 
+	variableBlocks := d.Get("spec.0.variable").([]interface{})
+	for idx := range variableBlocks {
+		pipeline.Spec.Variables = append(pipeline.Spec.Variables, cfClient.Variable{
+			Key:         d.Get(fmt.Sprintf("spec.0.variable.%v.key", idx)).(string),
+			Value:       d.Get(fmt.Sprintf("spec.0.variable.%v.value", idx)).(string),
+			Encrypted:   d.Get(fmt.Sprintf("spec.0.variable.%v.encrypted", idx)).(bool),
+			Description: d.Get(fmt.Sprintf("spec.0.variable.%v.description", idx)).(string),
+			Required:    d.Get(fmt.Sprintf("spec.0.variable.%v.required", idx)).(bool),
+		})
+	}
+
 	triggers := d.Get("spec.0.trigger").([]interface{})
 	for idx := range triggers {
-		events := d.Get(fmt.Sprintf("spec.0.trigger.%v.events", idx)).([]interface{})
-		contexts := d.Get(fmt.Sprintf("spec.0.trigger.%v.contexts", idx)).([]interface{})
-		codefreshTrigger := cfClient.Trigger{
-			Name:                         d.Get(fmt.Sprintf("spec.0.trigger.%v.name", idx)).(string),
-			Description:                  d.Get(fmt.Sprintf("spec.0.trigger.%v.description", idx)).(string),
-			Type:                         d.Get(fmt.Sprintf("spec.0.trigger.%v.type", idx)).(string),
-			Repo:                         d.Get(fmt.Sprintf("spec.0.trigger.%v.repo", idx)).(string),
-			BranchRegex:                  d.Get(fmt.Sprintf("spec.0.trigger.%v.branch_regex", idx)).(string),
-			BranchRegexInput:             d.Get(fmt.Sprintf("spec.0.trigger.%v.branch_regex_input", idx)).(string),
-			PullRequestTargetBranchRegex: d.Get(fmt.Sprintf("spec.0.trigger.%v.pull_request_target_branch_regex", idx)).(string),
-			CommentRegex:                 d.Get(fmt.Sprintf("spec.0.trigger.%v.comment_regex", idx)).(string),
-			ModifiedFilesGlob:            d.Get(fmt.Sprintf("spec.0.trigger.%v.modified_files_glob", idx)).(string),
-			Provider:                     d.Get(fmt.Sprintf("spec.0.trigger.%v.provider", idx)).(string),
-			Disabled:                     d.Get(fmt.Sprintf("spec.0.trigger.%v.disabled", idx)).(bool),
-			PullRequestAllowForkEvents:   d.Get(fmt.Sprintf("spec.0.trigger.%v.pull_request_allow_fork_events", idx)).(bool),
-			CommitStatusTitle:            d.Get(fmt.Sprintf("spec.0.trigger.%v.commit_status_title", idx)).(string),
-			Context:                      d.Get(fmt.Sprintf("spec.0.trigger.%v.context", idx)).(string),
-			Contexts:                     convertStringArr(contexts),
-			Events:                       convertStringArr(events),
-		}
-		variables := d.Get(fmt.Sprintf("spec.0.trigger.%v.variables", idx)).(map[string]interface{})
-		codefreshTrigger.SetVariables(variables)
-		if _, ok := d.GetOk(fmt.Sprintf("spec.0.trigger.%v.runtime_environment", idx)); ok {
-			triggerRuntime := cfClient.RuntimeEnvironment{
-				Name:        d.Get(fmt.Sprintf("spec.0.trigger.%v.runtime_environment.0.name", idx)).(string),
-				Memory:      d.Get(fmt.Sprintf("spec.0.trigger.%v.runtime_environment.0.memory", idx)).(string),
-				CPU:         d.Get(fmt.Sprintf("spec.0.trigger.%v.runtime_environment.0.cpu", idx)).(string),
-				DindStorage: d.Get(fmt.Sprintf("spec.0.trigger.%v.runtime_environment.0.dind_storage", idx)).(string),
-			}
-			codefreshTrigger.RuntimeEnvironment = &triggerRuntime
-		}
-		pipeline.Spec.Triggers = append(pipeline.Spec.Triggers, codefreshTrigger)
+		pipeline.Spec.Triggers = append(pipeline.Spec.Triggers, mapResourceToTrigger(d, fmt.Sprintf("spec.0.trigger.%v.", idx)))
 	}
 
 	var codefreshTerminationPolicy []map[string]interface{}
@@ -815,6 +1493,58 @@ func extractSpecAttributesFromOriginalYamlString(originalYamlString string, pipe
 
 }
 
+// normalizeStepsJSON converts the steps to a compact JSON object keeping the order of the steps, which is their order
+// of execution. As jsonencode() sorts the keys of the objects, the steps can also be a list of objects with a single
+// step each, e.g. [{"clone": {...}}, {"build": {...}}], which is converted to an object with the steps in the list order
+func normalizeStepsJSON(stepsJSON string) (string, error) {
+	steps := orderedmap.New()
+	steps.SetEscapeHTML(false)
+	if strings.HasPrefix(strings.TrimSpace(stepsJSON), "[") {
+		var stepList []orderedmap.OrderedMap
+		if err := json.Unmarshal([]byte(stepsJSON), &stepList); err != nil {
+			return stepsJSON, err
+		}
+		for _, step := range stepList {
+			for _, name := range step.Keys() {
+				value, _ := step.Get(name)
+				steps.Set(name, value)
+			}
+		}
+	} else if err := json.Unmarshal([]byte(stepsJSON), steps); err != nil {
+		return stepsJSON, err
+	}
+
+	// json.Marshal would escape the HTML characters such as the <> of the placeholders again
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(steps); err != nil {
+		return stepsJSON, err
+	}
+	return strings.TrimSuffix(normalized.String(), "\n"), nil
+}
+
+func validateStepsJSON(i interface{}, k string) (warnings []string, errors []error) {
+	if _, err := normalizeStepsJSON(i.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object of steps or a list of JSON objects: %v", k, err))
+	}
+	return warnings, errors
+}
+
+// suppressEquivalentStepsJSONDiffs ignores the differences of formatting and notation of the steps,
+// but not the changes of their order
+func suppressEquivalentStepsJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	normalizedOld, err := normalizeStepsJSON(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := normalizeStepsJSON(new)
+	if err != nil {
+		return false
+	}
+	return normalizedOld == normalizedNew
+}
+
 func getSupportedTerminationPolicyAttributes(policy string) map[string]interface{} {
 	switch policy {
 	case "on_create_branch":
@@ -840,3 +1570,106 @@ func convertOnCreateBranchAttributeToPipelineFormat(src string) string {
 		return "_" + strings.ToLower(w)
 	})
 }
+
+// triggerProviderKey provider is a reserved name at the root of a resource,
+// so the attribute is named git_provider in codefresh_pipeline_trigger
+func triggerProviderKey(prefix string) string {
+	if prefix == "" {
+		return "git_provider"
+	}
+	return prefix + "provider"
+}
+
+// keptTriggerWriteOnlyVariables returns the keys of the write-only variables of the trigger found under prefix
+// which aren't sent again. The state only has their keys, with empty values, the values configured can't be empty
+func keptTriggerWriteOnlyVariables(d *schema.ResourceData, prefix string) []string {
+	var keys []string
+	for key, value := range d.Get(prefix + "encrypted_variables_wo").(map[string]interface{}) {
+		if value.(string) == "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keepTriggerWriteOnlyVariables sets the values of the kept write-only variables of the trigger found under prefix
+// from the current triggers of the pipeline, read with decrypt
+func keepTriggerWriteOnlyVariables(d *schema.ResourceData, prefix string, trigger *cfClient.Trigger, currentTriggers []cfClient.Trigger) {
+	index := findTriggerIndex(currentTriggers, trigger.Name)
+	if index == -1 {
+		return
+	}
+	for _, key := range keptTriggerWriteOnlyVariables(d, prefix) {
+		for i, variable := range trigger.Variables {
+			if variable.Key != key || !variable.Encrypted {
+				continue
+			}
+			for _, current := range currentTriggers[index].Variables {
+				if current.Key == key {
+					trigger.Variables[i].Value = current.Value
+				}
+			}
+		}
+	}
+}
+
+// mapResourceToTrigger maps the trigger attributes found under prefix,
+// e.g. spec.0.trigger.0. for an inline trigger of the pipeline
+func mapResourceToTrigger(d *schema.ResourceData, prefix string) cfClient.Trigger {
+	events := d.Get(prefix + "events").([]interface{})
+	contexts := d.Get(prefix + "contexts").([]interface{})
+	codefreshTrigger := cfClient.Trigger{
+		Name:                         d.Get(prefix + "name").(string),
+		Description:                  d.Get(prefix + "description").(string),
+		Type:                         d.Get(prefix + "type").(string),
+		Repo:                         d.Get(prefix + "repo").(string),
+		SourcePipelineID:             d.Get(prefix + "source_pipeline_id").(string),
+		BranchRegex:                  normalizeBranchRegex(d.Get(prefix + "branch_regex").(string)),
+		BranchRegexInput:             d.Get(prefix + "branch_regex_input").(string),
+		PullRequestTargetBranchRegex: d.Get(prefix + "pull_request_target_branch_regex").(string),
+		CommentRegex:                 d.Get(prefix + "comment_regex").(string),
+		ModifiedFilesGlob:            d.Get(prefix + "modified_files_glob").(string),
+		Provider:                     renamedTriggerProviders.migrate(d.Get(triggerProviderKey(prefix)).(string)),
+		Disabled:                     d.Get(prefix + "disabled").(bool),
+		PullRequestAllowForkEvents:   d.Get(prefix + "pull_request_allow_fork_events").(bool),
+		PullRequestHeadRepoRegex:     d.Get(prefix + "pull_request_head_repo_regex").(string),
+		PullRequestForkExposeSecrets: d.Get(prefix + "pull_request_fork_expose_secrets").(bool),
+		CommitStatusTitle:            d.Get(prefix + "commit_status_title").(string),
+		CommitMessageRegex:           d.Get(prefix + "commit_message_regex").(string),
+		SkipCICheck:                  d.Get(prefix + "skip_ci_check").(bool),
+		Context:                      d.Get(prefix + "context").(string),
+		Contexts:                     convertStringArr(contexts),
+		Events:                       convertStringArr(events),
+	}
+	variables := d.Get(prefix + "variables").(map[string]interface{})
+	codefreshTrigger.SetVariables(variables)
+	encryptedVariables := d.Get(prefix + "encrypted_variables").(map[string]interface{})
+	codefreshTrigger.SetEncryptedVariables(encryptedVariables)
+	writeOnlyVariables := d.Get(prefix + "encrypted_variables_wo").(map[string]interface{})
+	codefreshTrigger.SetEncryptedVariables(writeOnlyVariables)
+	if _, ok := d.GetOk(prefix + "runtime_environment"); ok {
+		triggerRuntime := cfClient.RuntimeEnvironment{
+			Name:        d.Get(prefix + "runtime_environment.0.name").(string),
+			Memory:      d.Get(prefix + "runtime_environment.0.memory").(string),
+			CPU:         d.Get(prefix + "runtime_environment.0.cpu").(string),
+			DindStorage: d.Get(prefix + "runtime_environment.0.dind_storage").(string),
+		}
+		codefreshTrigger.RuntimeEnvironment = &triggerRuntime
+	}
+	if _, ok := d.GetOk(prefix + "options"); ok {
+		codefreshTrigger.Options = &cfClient.TriggerOptions{
+			NoCache:             d.Get(prefix + "options.0.no_cache").(bool),
+			NoCfCache:           d.Get(prefix + "options.0.no_cf_cache").(bool),
+			ResetVolume:         d.Get(prefix + "options.0.reset_volume").(bool),
+			EnableNotifications: d.Get(prefix + "options.0.enable_notifications").(bool),
+		}
+	}
+	annotations := d.Get(prefix + "annotations").(map[string]interface{})
+	if len(annotations) > 0 {
+		codefreshTrigger.Annotations = make(map[string]string, len(annotations))
+		for key, value := range annotations {
+			codefreshTrigger.Annotations[key] = value.(string)
+		}
+	}
+	return codefreshTrigger
+}
@@ -1,7 +1,9 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
 	"testing"
@@ -9,6 +11,7 @@ import (
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -137,6 +140,65 @@ func TestAccCodefreshPipeline_Variables(t *testing.T) {
 	})
 }
 
+func TestAccCodefreshPipeline_VariableBlocks(t *testing.T) {
+	name := pipelineNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_pipeline.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshPipelineBasicConfigVariableBlocks(name, "codefresh-contrib/react-sample-app", "./codefresh.yml", "master", "git", "Target environment", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshPipelineExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.key", "ENVIRONMENT"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.value", "staging"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.description", "Target environment"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.required", "true"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.1.key", "TOKEN"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.1.value", "secret"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.1.encrypted", "true"),
+				),
+			},
+			{
+				Config: testAccCodefreshPipelineBasicConfigVariableBlocks(name, "codefresh-contrib/react-sample-app", "./codefresh.yml", "master", "git", "Environment to deploy to", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshPipelineExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.description", "Environment to deploy to"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.variable.0.required", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCodefreshPipeline_Scopes(t *testing.T) {
+	name := pipelineNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_pipeline.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshPipelineBasicConfigScopes(name, "codefresh-contrib/react-sample-app", "./codefresh.yml", "master", "git", "pipeline:read", "build"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshPipelineExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccCodefreshPipeline_RuntimeEnvironment(t *testing.T) {
 	name := pipelineNamePrefix + acctest.RandString(10)
 	resourceName := "codefresh_pipeline.test"
@@ -284,6 +346,8 @@ func TestAccCodefreshPipeline_Triggers(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "spec.0.trigger.0.name", "commits"),
 					resource.TestCheckResourceAttr(resourceName, "spec.0.trigger.1.name", "tags"),
 					resource.TestCheckResourceAttr(resourceName, "spec.0.trigger.1.contexts.0", "shared_context2"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.trigger.1.commit_message_regex", "/^release.*/gi"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.trigger.1.skip_ci_check", "true"),
 				),
 			},
 			{
@@ -330,6 +394,243 @@ func TestAccCodefreshPipeline_Triggers(t *testing.T) {
 	})
 }
 
+func TestFlattenTriggerEncryptedVariables(t *testing.T) {
+	trigger := cfClient.Trigger{
+		Name: "commits",
+		Variables: []cfClient.Variable{
+			{Key: "ENVIRONMENT", Value: "staging"},
+			{Key: "TOKEN", Value: "*****", Encrypted: true},
+			{Key: "PASSWORD", Value: "*****", Encrypted: true},
+		},
+	}
+	stateEncryptedVariables := map[string]interface{}{"TOKEN": "secret"}
+
+	m := flattenTrigger(trigger, stateEncryptedVariables, nil)
+
+	if expected := map[string]string{"ENVIRONMENT": "staging"}; !reflect.DeepEqual(m["variables"], expected) {
+		t.Errorf("Expected variables %v, got %v", expected, m["variables"])
+	}
+	// the masked value is only used when the variable isn't in the state, e.g. on import
+	if expected := map[string]string{"TOKEN": "secret", "PASSWORD": "*****"}; !reflect.DeepEqual(m["encrypted_variables"], expected) {
+		t.Errorf("Expected encrypted variables %v, got %v", expected, m["encrypted_variables"])
+	}
+
+	// the values of the write-only variables aren't kept in the state
+	m = flattenTrigger(trigger, stateEncryptedVariables, map[string]interface{}{"PASSWORD": "secret"})
+	if expected := map[string]string{"TOKEN": "secret"}; !reflect.DeepEqual(m["encrypted_variables"], expected) {
+		t.Errorf("Expected encrypted variables %v, got %v", expected, m["encrypted_variables"])
+	}
+	if expected := map[string]string{"PASSWORD": ""}; !reflect.DeepEqual(m["encrypted_variables_wo"], expected) {
+		t.Errorf("Expected the keys of the write-only variables %v, got %v", expected, m["encrypted_variables_wo"])
+	}
+}
+
+func TestMergeUnmanagedTriggers(t *testing.T) {
+	configured := []cfClient.Trigger{{Name: "commits", Repo: "owner/repo-updated"}}
+	current := []cfClient.Trigger{{Name: "commits", Repo: "owner/repo"}, {Name: "removed"}, {Name: "added-in-ui"}}
+	previous := []interface{}{
+		map[string]interface{}{"name": "commits"},
+		map[string]interface{}{"name": "removed"},
+	}
+
+	merged := mergeUnmanagedTriggers(configured, current, previous)
+	expected := []cfClient.Trigger{{Name: "commits", Repo: "owner/repo-updated"}, {Name: "added-in-ui"}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected triggers %v, got %v", expected, merged)
+	}
+
+	managed := filterManagedTriggers(current, previous)
+	if len(managed) != 2 || managed[0].Name != "commits" || managed[1].Name != "removed" {
+		t.Errorf("Expected only the triggers of the state, got %v", managed)
+	}
+}
+
+func TestPipelineUpdateKeepsUnmanagedEncryptedVariables(t *testing.T) {
+	api := &maskedPipelineAPI{pipeline: cfClient.Pipeline{
+		Metadata: cfClient.Metadata{ID: "5f1fd9044d0fc94ddf9c5ce1", Name: "project/pipeline"},
+		Spec: cfClient.Spec{
+			Triggers: []cfClient.Trigger{{
+				Name:      "added-in-ui",
+				Variables: []cfClient.Variable{{Key: "KEY", Value: "trigger-secret", Encrypted: true}},
+			}},
+		},
+	}}
+	d := schema.TestResourceDataRaw(t, resourcePipeline().Schema, map[string]interface{}{
+		"name":                   "project/pipeline",
+		"triggers_authoritative": false,
+		"spec": []interface{}{
+			map[string]interface{}{
+				"trigger": []interface{}{map[string]interface{}{"name": "commits"}},
+			},
+		},
+	})
+	d.SetId("5f1fd9044d0fc94ddf9c5ce1")
+
+	if diags := resourcePipelineUpdate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	if api.updated == nil || len(api.updated.Spec.Triggers) != 2 {
+		t.Fatalf("Expected the configured trigger and the unmanaged one to be sent, got %+v", api.updated)
+	}
+	if values := encryptedValues(api.updated); fmt.Sprint(values) != "[trigger-secret]" {
+		t.Errorf("Expected the encrypted variables of the unmanaged trigger to be sent back unchanged, got %v", values)
+	}
+}
+
+func TestNormalizeStepsJSON(t *testing.T) {
+	expected := `{"clone":{"type":"git-clone","repo":"owner/repo"},"build":{"type":"build","tag":"<latest>"}}`
+	cases := map[string]string{
+		"object": `{
+			"clone": {"type": "git-clone", "repo": "owner/repo"},
+			"build": {"type": "build", "tag": "<latest>"}
+		}`,
+		"list of steps": `[{"clone": {"type": "git-clone", "repo": "owner/repo"}}, {"build": {"type": "build", "tag": "<latest>"}}]`,
+	}
+
+	for name, steps := range cases {
+		normalized, err := normalizeStepsJSON(steps)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if normalized != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, normalized)
+		}
+	}
+
+	// the order of the steps is their order of execution
+	if suppressEquivalentStepsJSONDiffs("", `{"a": {}, "b": {}}`, `{"b": {}, "a": {}}`, nil) {
+		t.Errorf("Expected the change of the order of the steps to be a diff")
+	}
+	if _, err := normalizeStepsJSON(`"clone"`); err == nil {
+		t.Errorf("Expected an error for steps which aren't an object")
+	}
+}
+
+func TestEffectiveConcurrency(t *testing.T) {
+	cases := []struct {
+		concurrency    int
+		parallelBuilds int
+		expected       int
+	}{
+		{0, 0, 0},
+		{3, 0, 3},
+		{0, 5, 5},
+		{3, 5, 3},
+		{10, 5, 5},
+	}
+	for _, c := range cases {
+		if effective := effectiveConcurrency(c.concurrency, c.parallelBuilds); effective != c.expected {
+			t.Errorf("effectiveConcurrency(%d, %d): expected %d, got %d", c.concurrency, c.parallelBuilds, c.expected, effective)
+		}
+	}
+}
+
+func TestPipelineCanonicalJSON(t *testing.T) {
+	pipeline := cfClient.Pipeline{
+		Metadata: cfClient.Metadata{
+			ID:        "5f1f0b9c",
+			Name:      "project/pipeline",
+			Revision:  3,
+			UpdatedAt: "2020-07-27T16:10:02.152Z",
+		},
+		Spec: cfClient.Spec{
+			Variables: []cfClient.Variable{{Key: "b", Value: "2"}},
+		},
+	}
+
+	canonical, checksum, err := pipelineCanonicalJSON(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"metadata":{"labels":{},"name":"project/pipeline"},"spec":{`
+	if len(canonical) < len(expected) || canonical[:len(expected)] != expected {
+		t.Errorf("Expected the canonical JSON to start with %s, got %s", expected, canonical)
+	}
+
+	pipeline.Metadata.Revision = 4
+	pipeline.Metadata.UpdatedAt = "2020-07-28T09:00:00.000Z"
+	if _, updatedChecksum, _ := pipelineCanonicalJSON(pipeline); updatedChecksum != checksum {
+		t.Error("Expected the checksum not to depend on the revision")
+	}
+
+	pipeline.Spec.Variables[0].Value = "3"
+	if _, updatedChecksum, _ := pipelineCanonicalJSON(pipeline); updatedChecksum == checksum {
+		t.Error("Expected the checksum to change with the spec")
+	}
+}
+
+func TestValidateTriggerNames(t *testing.T) {
+	for _, name := range []string{"commits", "Deploy to prod", "pr_v1.2-rc"} {
+		if _, errs := validateTriggerName(name, "name"); len(errs) > 0 {
+			t.Errorf("Expected the trigger name %q to be valid, got %v", name, errs)
+		}
+	}
+	for _, name := range []string{"-commits", "a/b", "deploy:prod"} {
+		if _, errs := validateTriggerName(name, "name"); len(errs) == 0 {
+			t.Errorf("Expected the trigger name %q to be invalid", name)
+		}
+	}
+
+	triggers := []interface{}{
+		map[string]interface{}{"name": "commits"},
+		map[string]interface{}{"name": ""},
+		map[string]interface{}{"name": ""},
+		map[string]interface{}{"name": "tags"},
+	}
+	if err := validateUniqueTriggerNames(triggers); err != nil {
+		t.Errorf("Expected the trigger names to be unique, got %v", err)
+	}
+	triggers = append(triggers, map[string]interface{}{"name": "commits"})
+	if err := validateUniqueTriggerNames(triggers); err == nil {
+		t.Error("Expected an error for the duplicate trigger name")
+	}
+}
+
+func TestValidateTrigger(t *testing.T) {
+	cases := map[string]struct {
+		trigger map[string]interface{}
+		valid   bool
+	}{
+		"git": {
+			trigger: map[string]interface{}{"type": "git", "repo": "owner/repo", "source_pipeline_id": "", "events": []interface{}{"push.heads"}},
+			valid:   true,
+		},
+		"git with source pipeline": {
+			trigger: map[string]interface{}{"type": "git", "repo": "owner/repo", "source_pipeline_id": "source", "events": []interface{}{}},
+		},
+		"codefresh": {
+			trigger: map[string]interface{}{"type": "codefresh", "repo": "", "source_pipeline_id": "source", "events": []interface{}{"build.success", "build.failure"}},
+			valid:   true,
+		},
+		"codefresh without source pipeline": {
+			trigger: map[string]interface{}{"type": "codefresh", "repo": "", "source_pipeline_id": "", "events": []interface{}{}},
+		},
+		"codefresh triggered by itself": {
+			trigger: map[string]interface{}{"type": "codefresh", "repo": "", "source_pipeline_id": "pipeline", "events": []interface{}{}},
+		},
+		"codefresh with repo": {
+			trigger: map[string]interface{}{"type": "codefresh", "repo": "owner/repo", "source_pipeline_id": "source", "events": []interface{}{}},
+		},
+		"codefresh with git event": {
+			trigger: map[string]interface{}{"type": "codefresh", "repo": "", "source_pipeline_id": "source", "events": []interface{}{"push.heads"}},
+		},
+		"encrypted variable also write-only": {
+			trigger: map[string]interface{}{"type": "git", "repo": "owner/repo", "source_pipeline_id": "", "events": []interface{}{"push.heads"},
+				"encrypted_variables": map[string]interface{}{"TOKEN": "secret"}, "encrypted_variables_wo": map[string]interface{}{"TOKEN": "secret"}},
+		},
+	}
+
+	for name, c := range cases {
+		err := validateTrigger("pipeline", func(key string) interface{} { return c.trigger[key] })
+		if c.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
 func TestAccCodefreshPipeline_Revision(t *testing.T) {
 	name := pipelineNamePrefix + acctest.RandString(10)
 	resourceName := "codefresh_pipeline.test"
@@ -627,6 +928,69 @@ resource "codefresh_pipeline" "test" {
 `, rName, repo, path, revision, context, var1Name, var1Value, var2Name, var2Value)
 }
 
+func testAccCodefreshPipelineBasicConfigVariableBlocks(rName, repo, path, revision, context, description string, required bool) string {
+	return fmt.Sprintf(`
+resource "codefresh_pipeline" "test" {
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  name = "%s"
+
+  spec {
+	spec_template {
+    	repo        = %q
+    	path        = %q
+    	revision    = %q
+    	context     = %q
+	}
+
+	variable {
+		key         = "ENVIRONMENT"
+		value       = "staging"
+		description = %q
+		required    = %t
+	}
+
+	variable {
+		key       = "TOKEN"
+		value     = "secret"
+		encrypted = true
+	}
+  }
+}
+`, rName, repo, path, revision, context, description, required)
+}
+
+func testAccCodefreshPipelineBasicConfigScopes(rName, repo, path, revision, context, scope1, scope2 string) string {
+	return fmt.Sprintf(`
+resource "codefresh_pipeline" "test" {
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  name = "%s"
+
+  scopes = [%q, %q]
+
+  spec {
+	spec_template {
+    	repo        = %q
+    	path        = %q
+    	revision    = %q
+    	context     = %q
+	}
+  }
+}
+`, rName, scope1, scope2, repo, path, revision, context)
+}
+
 func testAccCodefreshPipelineBasicConfigContexts(rName, repo, path, revision, context, sharedContext1, sharedContext2 string) string {
 	return fmt.Sprintf(`
 resource "codefresh_pipeline" "test" {
@@ -773,6 +1137,8 @@ resource "codefresh_pipeline" "test" {
 		}
 
 		commit_status_title = "%s"
+		commit_message_regex = "/^release.*/gi"
+		skip_ci_check = true
     }
   }
 }
@@ -849,6 +1215,37 @@ resource "codefresh_pipeline" "test" {
 `, rName, originalYamlString)
 }
 
+func TestAccCodefreshPipeline_SpecTemplateConflictsWithOriginalYamlString(t *testing.T) {
+	name := pipelineNamePrefix + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "codefresh_pipeline" "test" {
+  name = "%s"
+
+  original_yaml_string = "version: \"1.0\""
+
+  spec {
+	spec_template {
+    	repo        = "codefresh-contrib/react-sample-app"
+    	path        = "./codefresh.yml"
+    	revision    = "master"
+    	context     = "git"
+	}
+  }
+}
+`, name),
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+		},
+	})
+}
+
 func TestAccCodefreshPipeline_Contexts(t *testing.T) {
 	name := pipelineNamePrefix + acctest.RandString(10)
 	resourceName := "codefresh_pipeline.test"
@@ -996,3 +1393,116 @@ resource "codefresh_pipeline" "test" {
 }
 `, rName, repo, path, revision, context, isPublic)
 }
+
+// existingPipelineAPI mocks a pipeline created outside of terraform, the create fails with a conflict
+type existingPipelineAPI struct {
+	*maskedPipelineAPI
+}
+
+func (api *existingPipelineAPI) WithContext(ctx context.Context) cfClient.CodefreshAPI {
+	return api
+}
+
+func (api *existingPipelineAPI) CreatePipeline(pipeline *cfClient.Pipeline) (*cfClient.Pipeline, error) {
+	return nil, &cfClient.APIError{StatusCode: http.StatusConflict, Status: "409 Conflict"}
+}
+
+func (api *existingPipelineAPI) GetPipelineByName(name string) (*cfClient.Pipeline, error) {
+	return api.GetPipeline(name)
+}
+
+func TestPipelineCreateAdoptsExistingWithCheckRevision(t *testing.T) {
+	api := &existingPipelineAPI{&maskedPipelineAPI{pipeline: cfClient.Pipeline{
+		Metadata: cfClient.Metadata{ID: "5f1fd9044d0fc94ddf9c5ce1", Name: "project/pipeline", Revision: 7},
+	}}}
+	d := schema.TestResourceDataRaw(t, resourcePipeline().Schema, map[string]interface{}{
+		"name":           "project/pipeline",
+		"adopt_existing": true,
+		"check_revision": true,
+	})
+
+	if diags := resourcePipelineCreate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	if d.Id() != "5f1fd9044d0fc94ddf9c5ce1" || api.updated == nil {
+		t.Fatalf("Expected the existing pipeline to be adopted and updated, got %q %+v", d.Id(), api.updated)
+	}
+	if api.updated.Metadata.Revision != 7 {
+		t.Errorf("Expected the update of the revision 7, got %d", api.updated.Metadata.Revision)
+	}
+}
+
+func TestPipelineUpdateKeepsWriteOnlyTriggerVariables(t *testing.T) {
+	api := &maskedPipelineAPI{pipeline: cfClient.Pipeline{
+		Metadata: cfClient.Metadata{ID: "5f1fd9044d0fc94ddf9c5ce1", Name: "project/pipeline"},
+		Spec: cfClient.Spec{
+			Triggers: []cfClient.Trigger{{
+				Name:      "commits",
+				Variables: []cfClient.Variable{{Key: "TOKEN", Value: "trigger-secret", Encrypted: true}},
+			}},
+		},
+	}}
+	// the state only has the key of the write-only variable
+	d := schema.TestResourceDataRaw(t, resourcePipeline().Schema, map[string]interface{}{
+		"name": "project/pipeline",
+		"spec": []interface{}{
+			map[string]interface{}{
+				"trigger": []interface{}{map[string]interface{}{"name": "commits", "encrypted_variables_wo": map[string]interface{}{"TOKEN": ""}}},
+			},
+		},
+	})
+	d.SetId("5f1fd9044d0fc94ddf9c5ce1")
+
+	if diags := resourcePipelineUpdate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	if values := encryptedValues(api.updated); fmt.Sprint(values) != "[trigger-secret]" {
+		t.Errorf("Expected the current value of the write-only variable to be sent back, got %v", values)
+	}
+	if writeOnly := d.Get("spec.0.trigger.0.encrypted_variables_wo"); !reflect.DeepEqual(writeOnly, map[string]interface{}{"TOKEN": ""}) {
+		t.Errorf("Expected only the key of the write-only variable in the state, got %v", writeOnly)
+	}
+}
+
+func TestTriggerWriteOnlyVariablesDiff(t *testing.T) {
+	meta := cfClient.NewClient("", "", "", "")
+	state := &terraform.InstanceState{ID: "5f1fd9044d0fc94ddf9c5ce1", Attributes: map[string]string{
+		"id":                    "5f1fd9044d0fc94ddf9c5ce1",
+		"name":                  "project/pipeline",
+		"spec.#":                "1",
+		"spec.0.trigger.#":      "1",
+		"spec.0.trigger.0.name": "commits",
+		"spec.0.trigger.0.encrypted_variables_wo.%":       "1",
+		"spec.0.trigger.0.encrypted_variables_wo.TOKEN":   "",
+		"spec.0.trigger.0.encrypted_variables_wo_version": "1",
+	}}
+	config := func(name string, version int) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "project/pipeline",
+			"spec": []interface{}{map[string]interface{}{"trigger": []interface{}{map[string]interface{}{
+				"name":                           name,
+				"encrypted_variables_wo":         map[string]interface{}{"TOKEN": "rotated"},
+				"encrypted_variables_wo_version": version,
+			}}}},
+		})
+	}
+
+	for _, c := range []struct {
+		name    string
+		version int
+		sent    bool
+	}{
+		{"commits", 1, false},
+		{"commits", 2, true},
+		{"tags", 1, true},
+	} {
+		diff, err := resourcePipeline().Diff(context.Background(), state, config(c.name, c.version), meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent := diff != nil && diff.Attributes["spec.0.trigger.0.encrypted_variables_wo.TOKEN"] != nil
+		if sent != c.sent {
+			t.Errorf("trigger %s version %d: expected the write-only variable to be sent %v, got %v", c.name, c.version, c.sent, diff)
+		}
+	}
+}
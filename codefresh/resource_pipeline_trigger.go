@@ -0,0 +1,205 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pipelineLocks serializes the updates of a pipeline, the triggers are updated by sending the whole pipeline
+// so the codefresh_pipeline_trigger resources of the same pipeline would override each other
+var pipelineLocks sync.Map
+
+// lockPipeline locks the pipeline and returns the function to unlock it
+func lockPipeline(pipelineID string) func() {
+	lock, _ := pipelineLocks.LoadOrStore(pipelineID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+func resourcePipelineTrigger() *schema.Resource {
+	triggerSchema := pipelineTriggerSchema()
+	triggerSchema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validateTriggerName,
+	}
+	triggerSchema[triggerProviderKey("")] = triggerSchema["provider"]
+	delete(triggerSchema, "provider")
+	triggerSchema["pipeline_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return withDeprecations(&schema.Resource{
+		CreateContext: resourcePipelineTriggerCreate,
+		ReadContext:   resourcePipelineTriggerRead,
+		UpdateContext: resourcePipelineTriggerUpdate,
+		DeleteContext: resourcePipelineTriggerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return validateTrigger(d.Get("pipeline_id").(string), d.Get)
+		},
+		Schema: triggerSchema,
+	}, deprecation{
+		attribute:    triggerProviderKey(""),
+		legacyValues: renamedTriggerProviders,
+		migration:    renamedTriggerProvidersMigration,
+	})
+}
+
+func resourcePipelineTriggerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	pipelineID := d.Get("pipeline_id").(string)
+	trigger := mapResourceToTrigger(d, "")
+
+	err := updatePipelineTriggers(client, pipelineID, func(triggers []cfClient.Trigger) ([]cfClient.Trigger, error) {
+		if findTriggerIndex(triggers, trigger.Name) != -1 {
+			return nil, fmt.Errorf("[ERROR] Trigger %s already exists in pipeline %s", trigger.Name, pipelineID)
+		}
+		return append(triggers, trigger), nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", pipelineID, trigger.Name))
+
+	return resourcePipelineTriggerRead(ctx, d, meta)
+}
+
+func resourcePipelineTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	pipelineID, name, err := parsePipelineTriggerID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pipeline, err := client.GetPipeline(pipelineID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	index := findTriggerIndex(pipeline.Spec.Triggers, name)
+	if index == -1 {
+		d.SetId("")
+		return nil
+	}
+
+	err = d.Set("pipeline_id", pipelineID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stateEncryptedVariables := d.Get("encrypted_variables").(map[string]interface{})
+	stateWriteOnlyVariables := d.Get("encrypted_variables_wo").(map[string]interface{})
+	for key, value := range flattenTrigger(pipeline.Spec.Triggers[index], stateEncryptedVariables, stateWriteOnlyVariables) {
+		if key == "provider" {
+			key = triggerProviderKey("")
+		}
+		err = d.Set(key, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourcePipelineTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	pipelineID := d.Get("pipeline_id").(string)
+	trigger := mapResourceToTrigger(d, "")
+
+	err := updatePipelineTriggers(client, pipelineID, func(triggers []cfClient.Trigger) ([]cfClient.Trigger, error) {
+		index := findTriggerIndex(triggers, trigger.Name)
+		if index == -1 {
+			return nil, fmt.Errorf("[ERROR] Trigger %s doesn't exist in pipeline %s", trigger.Name, pipelineID)
+		}
+		keepTriggerWriteOnlyVariables(d, "", &trigger, triggers)
+		triggers[index] = trigger
+		return triggers, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePipelineTriggerRead(ctx, d, meta)
+}
+
+func resourcePipelineTriggerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	pipelineID, name, err := parsePipelineTriggerID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(updatePipelineTriggers(client, pipelineID, func(triggers []cfClient.Trigger) ([]cfClient.Trigger, error) {
+		index := findTriggerIndex(triggers, name)
+		if index == -1 {
+			return triggers, nil
+		}
+		return append(triggers[:index], triggers[index+1:]...), nil
+	}))
+}
+
+// updatePipelineTriggers applies update to the current triggers of the pipeline and saves the pipeline. The pipeline
+// is read with decrypt, so that the encrypted variables of the spec and of the other triggers are sent back unchanged
+func updatePipelineTriggers(client cfClient.CodefreshAPI, pipelineID string, update func([]cfClient.Trigger) ([]cfClient.Trigger, error)) error {
+	unlock := lockPipeline(pipelineID)
+	defer unlock()
+
+	pipeline, err := client.GetPipelineWithDecrypt(pipelineID, true)
+	if err != nil {
+		return err
+	}
+
+	triggers, err := update(pipeline.Spec.Triggers)
+	if err != nil {
+		return err
+	}
+	pipeline.Spec.Triggers = triggers
+
+	_, err = client.UpdatePipeline(pipeline)
+	return err
+}
+
+func findTriggerIndex(triggers []cfClient.Trigger, name string) int {
+	for i, trigger := range triggers {
+		if trigger.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// parsePipelineTriggerID splits the id of the resource, <pipeline id>/<trigger name>
+func parsePipelineTriggerID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("[ERROR] Invalid pipeline trigger ID %s, expected <pipeline id>/<trigger name>", id)
+	}
+	return parts[0], parts[1], nil
+}
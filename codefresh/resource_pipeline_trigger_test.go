@@ -0,0 +1,179 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCodefreshPipelineTrigger_basic(t *testing.T) {
+	name := pipelineNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_pipeline_trigger.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshPipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshPipelineTriggerConfig(name, "/master/gi"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshPipelineExists("codefresh_pipeline.test"),
+					resource.TestCheckResourceAttr(resourceName, "name", "commits"),
+					resource.TestCheckResourceAttr(resourceName, "branch_regex", "/master/gi"),
+					resource.TestCheckResourceAttr("codefresh_pipeline.test", "spec.0.trigger.#", "0"),
+				),
+			},
+			{
+				Config: testAccCodefreshPipelineTriggerConfig(name, "/release/gi"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "branch_regex", "/release/gi"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCodefreshPipelineTriggerConfig(rName, branchRegex string) string {
+	return fmt.Sprintf(`
+resource "codefresh_pipeline" "test" {
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  name = "%s"
+
+  manage_triggers_externally = true
+
+  spec {
+	spec_template {
+    	repo        = "codefresh-contrib/react-sample-app"
+    	path        = "./codefresh.yml"
+    	revision    = "master"
+    	context     = "git"
+	}
+  }
+}
+
+resource "codefresh_pipeline_trigger" "test" {
+  pipeline_id = codefresh_pipeline.test.id
+
+  name         = "commits"
+  repo         = "codefresh-contrib/react-sample-app"
+  branch_regex = %q
+  events       = ["push.heads"]
+}
+`, rName, branchRegex)
+}
+
+// maskedPipelineAPI mocks a pipeline whose encrypted variables are masked unless it's read with decrypt
+type maskedPipelineAPI struct {
+	cfClient.CodefreshAPI
+	pipeline cfClient.Pipeline
+	updated  *cfClient.Pipeline
+}
+
+func maskVariables(variables []cfClient.Variable) []cfClient.Variable {
+	masked := make([]cfClient.Variable, len(variables))
+	for i, variable := range variables {
+		masked[i] = variable
+		if variable.Encrypted {
+			masked[i].Value = "*****"
+		}
+	}
+	return masked
+}
+
+func (api *maskedPipelineAPI) WithContext(ctx context.Context) cfClient.CodefreshAPI {
+	return api
+}
+
+func (api *maskedPipelineAPI) GetPipeline(name string) (*cfClient.Pipeline, error) {
+	return api.GetPipelineWithDecrypt(name, false)
+}
+
+func (api *maskedPipelineAPI) GetPipelineWithDecrypt(name string, decrypt bool) (*cfClient.Pipeline, error) {
+	pipeline := api.pipeline
+	pipeline.Spec.Triggers = append([]cfClient.Trigger{}, api.pipeline.Spec.Triggers...)
+	if !decrypt {
+		pipeline.Spec.Variables = maskVariables(pipeline.Spec.Variables)
+		for i := range pipeline.Spec.Triggers {
+			pipeline.Spec.Triggers[i].Variables = maskVariables(pipeline.Spec.Triggers[i].Variables)
+		}
+	}
+	return &pipeline, nil
+}
+
+func (api *maskedPipelineAPI) GetPipelines(filter *cfClient.PipelinesFilter) ([]cfClient.Pipeline, error) {
+	pipeline, err := api.GetPipelineWithDecrypt(api.pipeline.Metadata.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	return []cfClient.Pipeline{*pipeline}, nil
+}
+
+func (api *maskedPipelineAPI) GetUIURL() string {
+	return "https://g.codefresh.io"
+}
+
+func (api *maskedPipelineAPI) GetCurrentAccountParallelBuilds() (int, error) {
+	return 0, nil
+}
+
+func (api *maskedPipelineAPI) UpdatePipeline(pipeline *cfClient.Pipeline) (*cfClient.Pipeline, error) {
+	api.updated = pipeline
+	return pipeline, nil
+}
+
+// encryptedValues returns the values of the encrypted variables of the spec and of the triggers of the pipeline
+func encryptedValues(pipeline *cfClient.Pipeline) []string {
+	var values []string
+	for _, variable := range pipeline.Spec.Variables {
+		if variable.Encrypted {
+			values = append(values, variable.Value)
+		}
+	}
+	for _, trigger := range pipeline.Spec.Triggers {
+		for _, variable := range trigger.Variables {
+			if variable.Encrypted {
+				values = append(values, variable.Value)
+			}
+		}
+	}
+	return values
+}
+
+func TestUpdatePipelineTriggersKeepsEncryptedValues(t *testing.T) {
+	api := &maskedPipelineAPI{pipeline: cfClient.Pipeline{
+		Metadata: cfClient.Metadata{ID: "5f1fd9044d0fc94ddf9c5ce1"},
+		Spec: cfClient.Spec{
+			Variables: []cfClient.Variable{{Key: "TOKEN", Value: "spec-secret", Encrypted: true}},
+			Triggers: []cfClient.Trigger{{
+				Name:      "ui",
+				Variables: []cfClient.Variable{{Key: "KEY", Value: "trigger-secret", Encrypted: true}},
+			}},
+		},
+	}}
+
+	err := updatePipelineTriggers(api, "5f1fd9044d0fc94ddf9c5ce1", func(triggers []cfClient.Trigger) ([]cfClient.Trigger, error) {
+		return append(triggers, cfClient.Trigger{Name: "commits"}), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values := encryptedValues(api.updated); fmt.Sprint(values) != "[spec-secret trigger-secret]" {
+		t.Errorf("Expected the encrypted values of the pipeline to be sent back unchanged, got %v", values)
+	}
+}
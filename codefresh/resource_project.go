@@ -1,23 +1,39 @@
 package codefresh
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// projectColorRegex the colors of the projects in the UI
+var projectColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Read:   resourceProjectRead,
-		Update: resourceProjectUpdate,
-		Delete: resourceProjectDelete,
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if variables, ok := d.GetOk("variables"); ok && d.Get("manage_variables_externally").(bool) && len(variables.(map[string]interface{})) > 0 {
+				return fmt.Errorf("variables can't be set when manage_variables_externally is enabled, use codefresh_project_variables instead")
+			}
+			return customizeTagsAll(d, meta)
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -30,6 +46,37 @@ func resourceProject() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"exclude_default_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags_all": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"icon": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(projectColorRegex, "must be a hex color, e.g. #1f78d1"),
+				// the color is sent in lower case, #1F78D1 and #1f78d1 are the same color
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
 			"variables": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -37,28 +84,56 @@ func resourceProject() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"manage_variables_externally": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"adopt_existing": adoptExistingSchema(),
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"builds_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceProjectCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	project := *mapResourceToProject(d)
+	project.Tags = getTagsWithDefaults(d, meta)
 
 	resp, err := client.CreateProject(&project)
+	if cfClient.IsConflictError(err) && getAdoptExisting(d, meta) {
+		existingProject, err := client.GetProjectByName(project.ProjectName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] Adopting the existing project %s", project.ProjectName)
+		d.SetId(existingProject.ID)
+		diags := resourceProjectUpdate(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return resourceProjectRead(ctx, d, meta)
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(resp.ID)
 
-	return nil
+	return readCreatedEntity(ctx, d, meta, resourceProjectRead, resourceProjectDelete)
 }
 
-func resourceProjectRead(d *schema.ResourceData, meta interface{}) error {
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	projectID := d.Id()
 	if projectID == "" {
@@ -68,32 +143,57 @@ func resourceProjectRead(d *schema.ResourceData, meta interface{}) error {
 
 	project, err := client.GetProjectByID(projectID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapProjectToResource(project, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	err = setTagsWithoutDefaults(project.Tags, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("url", fmt.Sprintf("%s/projects/%s/edit/pipelines/?projectId=%s", client.GetUIURL(), url.PathEscape(project.ProjectName), url.QueryEscape(projectID)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("builds_url", fmt.Sprintf("%s/builds2?filter=project:%s", client.GetUIURL(), url.QueryEscape(projectID)))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceProjectUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	project := *mapResourceToProject(d)
+	project.Tags = getTagsWithDefaults(d, meta)
+
+	unlock := lockProject(d.Id())
+	defer unlock()
+
+	if d.Get("manage_variables_externally").(bool) {
+		// the variables managed by the codefresh_project_variables resource are left out of the PATCH, sending
+		// back the variables read from the API would overwrite the encrypted values with their masked values
+		project.Variables = nil
+	}
 
 	err := client.UpdateProject(&project)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceProjectDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 	// Adding a Retry backoff to address eventual consistency for the API
 	expBackoff := backoff.NewExponentialBackOff()
 	expBackoff.MaxElapsedTime = 2 * time.Second
@@ -106,7 +206,7 @@ func resourceProjectDelete(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}, expBackoff)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -124,7 +224,26 @@ func mapProjectToResource(project *cfClient.Project, d *schema.ResourceData) err
 		return err
 	}
 
-	err = d.Set("variables", convertVariables(project.Variables))
+	err = d.Set("description", project.Description)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("icon", project.Icon)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("color", project.Color)
+	if err != nil {
+		return err
+	}
+
+	variables := project.Variables
+	if d.Get("manage_variables_externally").(bool) {
+		variables = nil
+	}
+	err = d.Set("variables", convertVariables(variables))
 	if err != nil {
 		return err
 	}
@@ -137,6 +256,9 @@ func mapResourceToProject(d *schema.ResourceData) *cfClient.Project {
 		ID:          d.Id(),
 		ProjectName: d.Get("name").(string),
 		Tags:        convertStringArr(tags),
+		Description: d.Get("description").(string),
+		Icon:        d.Get("icon").(string),
+		Color:       strings.ToLower(d.Get("color").(string)),
 	}
 	variables := d.Get("variables").(map[string]interface{})
 	project.SetVariables(variables)
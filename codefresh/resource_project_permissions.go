@@ -0,0 +1,221 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceProjectPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectPermissionsCreate,
+		ReadContext:   resourceProjectPermissionsRead,
+		UpdateContext: resourceProjectPermissionsUpdate,
+		DeleteContext: resourceProjectPermissionsDelete,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"team": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"team_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"actions": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"create", "read", "update", "delete", "run", "approve", "debug"}, false),
+							},
+						},
+					},
+				},
+			},
+			"permission_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceProjectPermissionsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	projectID := d.Get("project_id").(string)
+
+	tag := d.Get("tag").(string)
+	if tag == "" {
+		project, err := client.GetProjectByID(projectID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tag = project.ProjectName
+	}
+
+	d.SetId(projectID)
+	err := d.Set("tag", tag)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = syncProjectPermissions(client, d, map[string]string{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectPermissionsRead(ctx, d, meta)
+}
+
+func resourceProjectPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	permissions, err := client.GetPermissionList("", "", "pipeline")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	existingPermissions := make(map[string]cfClient.Permission, len(permissions))
+	for _, permission := range permissions {
+		existingPermissions[permission.ID] = permission
+	}
+
+	// the rules deleted outside of Terraform are removed from the state, so that they are created again
+	permissionIDs := map[string]string{}
+	teamActions := map[string][]string{}
+	for key, id := range d.Get("permission_ids").(map[string]interface{}) {
+		permission, ok := existingPermissions[id.(string)]
+		if !ok {
+			continue
+		}
+		permissionIDs[key] = permission.ID
+		teamActions[permission.Team] = append(teamActions[permission.Team], permission.Action)
+	}
+
+	var teams []map[string]interface{}
+	for teamID, actions := range teamActions {
+		teams = append(teams, map[string]interface{}{
+			"team_id": teamID,
+			"actions": actions,
+		})
+	}
+
+	err = d.Set("team", teams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("permission_ids", permissionIDs))
+}
+
+func resourceProjectPermissionsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	currentIDs := map[string]string{}
+	for key, id := range d.Get("permission_ids").(map[string]interface{}) {
+		currentIDs[key] = id.(string)
+	}
+
+	err := syncProjectPermissions(client, d, currentIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectPermissionsRead(ctx, d, meta)
+}
+
+func resourceProjectPermissionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	for _, id := range d.Get("permission_ids").(map[string]interface{}) {
+		err := client.DeletePermission(id.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// syncProjectPermissions creates the rules of the team actions which don't exist yet and deletes
+// the rules of the removed team actions. The rules are keyed by <team id>/<action>
+func syncProjectPermissions(client cfClient.CodefreshAPI, d *schema.ResourceData, currentIDs map[string]string) error {
+
+	desiredKeys := map[string]bool{}
+	for _, team := range d.Get("team").(*schema.Set).List() {
+		team := team.(map[string]interface{})
+		for _, action := range convertStringArr(team["actions"].(*schema.Set).List()) {
+			desiredKeys[fmt.Sprintf("%s/%s", team["team_id"].(string), action)] = true
+		}
+	}
+
+	return syncPipelineTagPermissions(client, d, d.Get("tag").(string), desiredKeys, currentIDs)
+}
+
+// syncPipelineTagPermissions creates the pipeline rules of the desired <team id>/<action> keys restricted
+// to the tag and deletes the current rules which aren't desired anymore, the IDs are stored in permission_ids
+func syncPipelineTagPermissions(client cfClient.CodefreshAPI, d *schema.ResourceData, tag string, desiredKeys map[string]bool, currentIDs map[string]string) error {
+
+	permissionIDs := map[string]string{}
+	for key, id := range currentIDs {
+		if desiredKeys[key] {
+			permissionIDs[key] = id
+			continue
+		}
+		err := client.DeletePermission(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range desiredKeys {
+		if _, ok := permissionIDs[key]; ok {
+			continue
+		}
+		parts := strings.SplitN(key, "/", 2)
+		permission, err := client.CreatePermission(&cfClient.Permission{
+			Team:     parts[0],
+			Action:   parts[1],
+			Resource: cfClient.PermissionResourcePipeline,
+			Tags:     []string{tag},
+		})
+		if err != nil {
+			// keep the rules created so far, so that they are deleted with the resource
+			d.Set("permission_ids", permissionIDs)
+			return err
+		}
+		permissionIDs[key] = permission.ID
+	}
+
+	return d.Set("permission_ids", permissionIDs)
+}
@@ -0,0 +1,63 @@
+package codefresh
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCodefreshProjectPermissions_basic(t *testing.T) {
+	name := workflowNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_project_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCodefreshPermissionDestroy,
+			testAccCheckCodefreshTeamDestroy,
+			testAccCheckCodefreshProjectDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshProjectPermissionsConfig(name, `"run", "read"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag", name),
+					resource.TestCheckResourceAttr(resourceName, "team.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "permission_ids.%", "2"),
+				),
+			},
+			{
+				// removing an action deletes only its rule
+				Config: testAccCodefreshProjectPermissionsConfig(name, `"run"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission_ids.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCodefreshProjectPermissionsConfig(rName, actions string) string {
+	return fmt.Sprintf(`
+resource "codefresh_project" "test" {
+  name = "%[1]s"
+  tags = ["%[1]s"]
+}
+
+resource "codefresh_team" "test" {
+  name = "%[1]s"
+}
+
+resource "codefresh_project_permissions" "test" {
+  project_id = codefresh_project.test.id
+
+  team {
+    team_id = codefresh_team.test.id
+    actions = [%[2]s]
+  }
+}
+`, rName, actions)
+}
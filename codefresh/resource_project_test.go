@@ -26,6 +26,8 @@ func TestAccCodefreshProject_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckCodefreshProjectExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
+					resource.TestCheckResourceAttrSet(resourceName, "builds_url"),
 				),
 			},
 			{
@@ -63,6 +65,41 @@ func TestAccCodefreshProject_Tags(t *testing.T) {
 	})
 }
 
+func TestAccCodefreshProject_Metadata(t *testing.T) {
+	name := projectNamePrefix + acctest.RandString(10)
+	resourceName := "codefresh_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCodefreshProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshProjectBasicConfigMetadata(name, "The services of the payments team", "#1f78d1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshProjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", "The services of the payments team"),
+					resource.TestCheckResourceAttr(resourceName, "icon", "💳"),
+					resource.TestCheckResourceAttr(resourceName, "color", "#1f78d1"),
+				),
+			},
+			{
+				// the case of the color doesn't show a diff
+				Config:   testAccCodefreshProjectBasicConfigMetadata(name, "The services of the payments team", "#1F78D1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccCodefreshProjectBasicConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshProjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "color", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCodefreshProject_Variables(t *testing.T) {
 	name := projectNamePrefix + acctest.RandString(10)
 	resourceName := "codefresh_project.test"
@@ -166,6 +203,17 @@ resource "codefresh_project" "test" {
 `, rName, tag1, tag2)
 }
 
+func testAccCodefreshProjectBasicConfigMetadata(rName, description, color string) string {
+	return fmt.Sprintf(`
+resource "codefresh_project" "test" {
+  name        = "%s"
+  description = %q
+  icon        = "💳"
+  color       = %q
+}
+`, rName, description, color)
+}
+
 func testAccCodefreshProjectBasicConfigVariables(rName, var1Name, var1Value, var2Name, var2Value string) string {
 	return fmt.Sprintf(`
 resource "codefresh_project" "test" {
@@ -0,0 +1,158 @@
+package codefresh
+
+import (
+	"context"
+	"sync"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectLocks serializes the updates of a project, so that the update of a project with
+// manage_variables_externally doesn't override the variables saved by codefresh_project_variables
+var projectLocks sync.Map
+
+// lockProject locks the project and returns the function to unlock it
+func lockProject(projectID string) func() {
+	lock, _ := projectLocks.LoadOrStore(projectID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+func resourceProjectVariables() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectVariablesCreate,
+		ReadContext:   resourceProjectVariablesRead,
+		UpdateContext: resourceProjectVariablesUpdate,
+		DeleteContext: resourceProjectVariablesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"encrypted_variables": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceProjectVariablesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	projectID := d.Get("project_id").(string)
+
+	unlock := lockProject(projectID)
+	defer unlock()
+
+	err := client.UpdateProjectVariables(projectID, mapResourceToProjectVariables(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectID)
+
+	return resourceProjectVariablesRead(ctx, d, meta)
+}
+
+func resourceProjectVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	projectID := d.Id()
+	if projectID == "" {
+		d.SetId("")
+		return nil
+	}
+
+	project, err := client.GetProjectByID(projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("project_id", projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(mapProjectVariablesToResource(project.Variables, d))
+}
+
+func resourceProjectVariablesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	unlock := lockProject(d.Id())
+	defer unlock()
+
+	err := client.UpdateProjectVariables(d.Id(), mapResourceToProjectVariables(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectVariablesRead(ctx, d, meta)
+}
+
+func resourceProjectVariablesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	unlock := lockProject(d.Id())
+	defer unlock()
+
+	return diag.FromErr(client.UpdateProjectVariables(d.Id(), nil))
+}
+
+// mapProjectVariablesToResource the API masks the values of the encrypted variables, so their values are kept from the state
+func mapProjectVariablesToResource(projectVariables []cfClient.Variable, d *schema.ResourceData) error {
+	stateEncryptedVariables := d.Get("encrypted_variables").(map[string]interface{})
+
+	variables := map[string]string{}
+	encryptedVariables := map[string]string{}
+	for _, variable := range projectVariables {
+		if !variable.Encrypted {
+			variables[variable.Key] = variable.Value
+			continue
+		}
+		encryptedVariables[variable.Key] = variable.Value
+		if stateValue, ok := stateEncryptedVariables[variable.Key].(string); ok {
+			encryptedVariables[variable.Key] = stateValue
+		}
+	}
+
+	err := d.Set("variables", variables)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("encrypted_variables", encryptedVariables)
+}
+
+func mapResourceToProjectVariables(d *schema.ResourceData) []cfClient.Variable {
+	var variables []cfClient.Variable
+	for key, value := range d.Get("variables").(map[string]interface{}) {
+		variables = append(variables, cfClient.Variable{Key: key, Value: value.(string)})
+	}
+	for key, value := range d.Get("encrypted_variables").(map[string]interface{}) {
+		variables = append(variables, cfClient.Variable{Key: key, Value: value.(string), Encrypted: true})
+	}
+	return variables
+}
@@ -0,0 +1,73 @@
+package codefresh
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMapProjectVariablesToResource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProjectVariables().Schema, map[string]interface{}{
+		"project_id":          "project",
+		"encrypted_variables": map[string]interface{}{"TOKEN": "secret"},
+	})
+
+	variables := []cfClient.Variable{
+		{Key: "ENVIRONMENT", Value: "staging"},
+		{Key: "TOKEN", Value: "*****", Encrypted: true},
+		{Key: "PASSWORD", Value: "*****", Encrypted: true},
+	}
+
+	err := mapProjectVariablesToResource(variables, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := map[string]interface{}{"ENVIRONMENT": "staging"}; !reflect.DeepEqual(d.Get("variables"), expected) {
+		t.Errorf("Expected variables %v, got %v", expected, d.Get("variables"))
+	}
+	// the masked value is only used when the variable isn't in the state, e.g. on import
+	if expected := map[string]interface{}{"TOKEN": "secret", "PASSWORD": "*****"}; !reflect.DeepEqual(d.Get("encrypted_variables"), expected) {
+		t.Errorf("Expected encrypted variables %v, got %v", expected, d.Get("encrypted_variables"))
+	}
+}
+
+// projectUpdateAPI records the projects sent by the updates
+type projectUpdateAPI struct {
+	cfClient.CodefreshAPI
+	updated *cfClient.Project
+}
+
+func (api *projectUpdateAPI) WithContext(ctx context.Context) cfClient.CodefreshAPI {
+	return api
+}
+
+func (api *projectUpdateAPI) UpdateProject(project *cfClient.Project) error {
+	api.updated = project
+	return nil
+}
+
+func TestProjectUpdateWithExternalVariables(t *testing.T) {
+	api := &projectUpdateAPI{}
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":                        "project",
+		"description":                 "renamed",
+		"manage_variables_externally": true,
+	})
+	d.SetId("5f1fd9044d0fc94ddf9c5ce1")
+
+	if diags := resourceProjectUpdate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Unexpected error %v", diags)
+	}
+	body, err := cfClient.EncodeToJSON(api.updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "variables") {
+		t.Errorf("Expected the variables managed externally to be left out of the update, got %s", body)
+	}
+}
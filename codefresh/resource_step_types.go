@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/iancoleman/orderedmap"
 	"gopkg.in/yaml.v2"
 )
@@ -59,6 +60,26 @@ func resourceStepTypes() *schema.Resource {
 					},
 				},
 			},
+			"publish": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"set_latest": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"deprecate_older_than": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -87,7 +108,7 @@ func normalizeYamlStringStepTypes(yamlString interface{}) (string, error) {
 
 func resourceStepTypesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI)
 	stepTypes := *mapResourceToStepTypesVersions(d)
 
 	name := d.Get("name").(string)
@@ -117,11 +138,16 @@ func resourceStepTypesCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
+	err := publishStepTypesVersions(client, d, versions)
+	if err != nil {
+		return diag.Errorf("[DEBUG] Error while publishing step types OnCreate. Error = %v", err)
+	}
+
 	return resourceStepTypesRead(ctx, d, meta)
 }
 
 func resourceStepTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI)
 
 	stepTypesIdentifier := d.Id()
 	if stepTypesIdentifier == "" {
@@ -152,6 +178,10 @@ func resourceStepTypesRead(ctx context.Context, d *schema.ResourceData, meta int
 			if err != nil {
 				log.Printf("[DEBUG] StepVersion not found %v. Error = %v", stepTypesIdentifier+":"+version, err)
 			} else {
+				if d.Get("publish.0.deprecate_older_than").(int) > 0 {
+					// the deprecation is managed by the publish block, not by the yaml of the versions
+					delete(stepTypes.Metadata, "deprecated")
+				}
 				cleanUpStepFromTransientValues(stepTypes, name, version)
 				stepVersion := cfClient.StepTypesVersion{
 					VersionNumber: version,
@@ -174,7 +204,7 @@ func resourceStepTypesRead(ctx context.Context, d *schema.ResourceData, meta int
 
 func resourceStepTypesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI)
 	name := d.Get("name").(string)
 	stepTypesVersions := mapResourceToStepTypesVersions(d)
 	mapVersionToCreate := make(map[string]cfClient.StepTypes)
@@ -241,12 +271,21 @@ func resourceStepTypesUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
+	var versions []string
+	for version := range versionsDefined {
+		versions = append(versions, version)
+	}
+	err := publishStepTypesVersions(client, d, versions)
+	if err != nil {
+		return diag.Errorf("[DEBUG] Error while publishing step types OnUpdate. Error = %v", err)
+	}
+
 	return resourceStepTypesRead(ctx, d, meta)
 }
 
 func resourceStepTypesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI)
 	log.Printf("[DEBUG] Deleting step type: %s", d.Id())
 	err := client.DeleteStepTypes(d.Id())
 	if err != nil {
@@ -287,6 +326,50 @@ func cleanUpStepFromTransientValues(stepTypes *cfClient.StepTypes, name, version
 
 }
 
+// publishStepTypesVersions marks the highest version as latest and deprecates the versions older than
+// the deprecate_older_than most recent ones, as configured in the publish block
+func publishStepTypesVersions(client cfClient.CodefreshAPI, d *schema.ResourceData, versions []string) error {
+	if _, ok := d.GetOk("publish"); !ok || len(versions) == 0 {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	setLatest := d.Get("publish.0.set_latest").(bool)
+	deprecateOlderThan := d.Get("publish.0.deprecate_older_than").(int)
+
+	orderedVersions := sortVersions(versions)
+	for i, version := range orderedVersions {
+		latest := setLatest && i == len(orderedVersions)-1
+		if !latest && deprecateOlderThan == 0 {
+			// the versions keep the latest=false set on create and update
+			continue
+		}
+
+		stepTypes, err := client.GetStepTypes(name + ":" + version.Original())
+		if err != nil {
+			return err
+		}
+		cleanUpStepFromTransientValues(stepTypes, name, version.Original())
+		stepTypes.Metadata["latest"] = latest
+		if deprecateOlderThan > 0 {
+			stepTypes.Metadata["deprecated"] = isStepTypesVersionDeprecated(i, len(orderedVersions), deprecateOlderThan)
+		}
+
+		log.Printf("[DEBUG] Publishing version %s of step types %s, latest: %v", version.Original(), name, latest)
+		_, err = client.UpdateStepTypes(stepTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStepTypesVersionDeprecated whether the version at index of the ordered versions isn't one of the keep most recent ones
+func isStepTypesVersionDeprecated(index, count, keep int) bool {
+	return keep > 0 && index < count-keep
+}
+
 func sortVersions(versions []string) []*semver.Version {
 	log.Printf("[DEBUG] Sorting: %q", versions)
 	var vs []*semver.Version
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -83,6 +84,35 @@ func TestSortVersions(t *testing.T) {
 	}
 }
 
+func TestFilterStepTypeVersions(t *testing.T) {
+	versions := []string{"2.13.0", "1.0.0", "latest", "1.10.1", "0.12.1", "1.2.8"}
+
+	if filtered := filterStepTypeVersions(versions, nil); !reflect.DeepEqual(filtered, []string{"0.12.1", "1.0.0", "1.2.8", "1.10.1", "2.13.0"}) {
+		t.Errorf("Expected all the semver versions in order, got %v", filtered)
+	}
+
+	constraint, err := semver.NewConstraint("~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filtered := filterStepTypeVersions(versions, constraint); !reflect.DeepEqual(filtered, []string{"1.0.0", "1.2.8", "1.10.1"}) {
+		t.Errorf("Expected the 1.x versions in order, got %v", filtered)
+	}
+}
+
+func TestIsStepTypesVersionDeprecated(t *testing.T) {
+	// 5 versions ordered by semver, the 2 most recent ones are kept
+	expected := []bool{true, true, true, false, false}
+	for index, deprecated := range expected {
+		if isStepTypesVersionDeprecated(index, len(expected), 2) != deprecated {
+			t.Errorf("Expected deprecated %v for the version at index %d", deprecated, index)
+		}
+	}
+	if isStepTypesVersionDeprecated(0, 5, 0) {
+		t.Errorf("Expected no deprecation when the number of versions to keep is 0")
+	}
+}
+
 func TestExtractSteps(t *testing.T) {
 	testFile := "../test_data/step_types/testStepTypesOrder.yaml"
 	yamlString, err := ioutil.ReadFile(testFile)
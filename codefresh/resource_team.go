@@ -1,19 +1,21 @@
 package codefresh
 
 import (
+	"context"
 	"fmt"
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTeamCreate,
-		Read:   resourceTeamRead,
-		Update: resourceTeamUpdate,
-		Delete: resourceTeamDelete,
+		CreateContext: resourceTeamCreate,
+		ReadContext:   resourceTeamRead,
+		UpdateContext: resourceTeamUpdate,
+		DeleteContext: resourceTeamDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importTeamState,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -47,14 +49,14 @@ func resourceTeam() *schema.Resource {
 	}
 }
 
-func resourceTeamCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	team := *mapResourceToTeam(d)
 
 	resp, err := client.CreateTeam(&team)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(resp.ID)
@@ -63,9 +65,9 @@ func resourceTeamCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceTeamRead(d *schema.ResourceData, meta interface{}) error {
+func resourceTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	teamID := d.Id()
 	if teamID == "" {
@@ -75,26 +77,26 @@ func resourceTeamRead(d *schema.ResourceData, meta interface{}) error {
 
 	team, err := client.GetTeamByID(teamID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapTeamToResource(team, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceTeamUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	team := *mapResourceToTeam(d)
 
 	// Rename
 	err := client.RenameTeam(team.ID, team.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update users
@@ -110,26 +112,26 @@ func resourceTeamUpdate(d *schema.ResourceData, meta interface{}) error {
 	for _, userId := range usersToDelete {
 		err := client.DeleteUserFromTeam(team.ID, userId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	for _, userId := range usersToAdd {
 		err := client.AddUserToTeam(team.ID, userId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceTeamDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*cfClient.Client)
+func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	err := client.DeleteTeam(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
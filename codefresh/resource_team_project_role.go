@@ -0,0 +1,181 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// projectRoleActions the pipeline actions granted by the default roles of a team in a project,
+// the API has no project roles so they are emulated with permissions restricted to the project tag
+var projectRoleActions = map[string][]string{
+	"viewer": {"read"},
+	"editor": {"create", "read", "update", "delete", "run"},
+}
+
+func resourceTeamProjectRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamProjectRoleCreate,
+		ReadContext:   resourceTeamProjectRoleRead,
+		UpdateContext: resourceTeamProjectRoleUpdate,
+		DeleteContext: resourceTeamProjectRoleDelete,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"viewer", "editor"}, false),
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"permission_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceTeamProjectRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	projectID := d.Get("project_id").(string)
+
+	tag := d.Get("tag").(string)
+	if tag == "" {
+		project, err := client.GetProjectByID(projectID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tag = project.ProjectName
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, d.Get("team_id").(string)))
+	err := d.Set("tag", tag)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = syncPipelineTagPermissions(client, d, tag, teamProjectRoleKeys(d), map[string]string{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTeamProjectRoleRead(ctx, d, meta)
+}
+
+func resourceTeamProjectRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	permissions, err := client.GetPermissionList(d.Get("team_id").(string), "", "pipeline")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	existingPermissions := make(map[string]cfClient.Permission, len(permissions))
+	for _, permission := range permissions {
+		existingPermissions[permission.ID] = permission
+	}
+
+	// the rules deleted outside of Terraform are removed from the state, so that they are created again
+	permissionIDs := map[string]string{}
+	var actions []string
+	for key, id := range d.Get("permission_ids").(map[string]interface{}) {
+		permission, ok := existingPermissions[id.(string)]
+		if !ok {
+			continue
+		}
+		permissionIDs[key] = permission.ID
+		actions = append(actions, permission.Action)
+	}
+
+	err = d.Set("role", projectRoleOfActions(actions))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("permission_ids", permissionIDs))
+}
+
+func resourceTeamProjectRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	currentIDs := map[string]string{}
+	for key, id := range d.Get("permission_ids").(map[string]interface{}) {
+		currentIDs[key] = id.(string)
+	}
+
+	err := syncPipelineTagPermissions(client, d, d.Get("tag").(string), teamProjectRoleKeys(d), currentIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTeamProjectRoleRead(ctx, d, meta)
+}
+
+func resourceTeamProjectRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	for _, id := range d.Get("permission_ids").(map[string]interface{}) {
+		err := client.DeletePermission(id.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// teamProjectRoleKeys returns the <team id>/<action> keys of the actions granted by the role
+func teamProjectRoleKeys(d *schema.ResourceData) map[string]bool {
+	keys := map[string]bool{}
+	for _, action := range projectRoleActions[d.Get("role").(string)] {
+		keys[fmt.Sprintf("%s/%s", d.Get("team_id").(string), action)] = true
+	}
+	return keys
+}
+
+// projectRoleOfActions returns the role granting exactly the actions, or an empty string
+// when the rules were changed outside of Terraform so that the role is applied again
+func projectRoleOfActions(actions []string) string {
+	sorted := append([]string{}, actions...)
+	sort.Strings(sorted)
+	for role, roleActions := range projectRoleActions {
+		expected := append([]string{}, roleActions...)
+		sort.Strings(expected)
+		if fmt.Sprint(sorted) == fmt.Sprint(expected) {
+			return role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package codefresh
+
+import (
+	"testing"
+)
+
+func TestProjectRoleOfActions(t *testing.T) {
+	tests := []struct {
+		actions []string
+		role    string
+	}{
+		{[]string{"read"}, "viewer"},
+		{[]string{"run", "read", "delete", "update", "create"}, "editor"},
+		{[]string{"read", "run"}, ""},
+		{nil, ""},
+	}
+
+	for _, test := range tests {
+		if role := projectRoleOfActions(test.actions); role != test.role {
+			t.Errorf("Expected the role of %v to be %q, got %q", test.actions, test.role, role)
+		}
+	}
+}
@@ -1,17 +1,21 @@
 package codefresh
 
 import (
+	"context"
+
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 )
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUsersCreate,
-		Read:   resourceUsersRead,
-		Update: resourceUsersUpdate,
-		Delete: resourceUsersDelete,
+		CreateContext: resourceUsersCreate,
+		ReadContext:   resourceUsersRead,
+		UpdateContext: resourceUsersUpdate,
+		DeleteContext: resourceUsersDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -25,8 +29,39 @@ func resourceUser() *schema.Resource {
 				Required: true,
 			},
 			"activate": {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"invite"},
+			},
+			"invite": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				MaxItems:         1,
+				DiffSuppressFunc: suppressInvitationDiff,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"send_email": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Default:          true,
+							DiffSuppressFunc: suppressInvitationDiff,
+						},
+						"message": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressInvitationDiff,
+						},
+						"idp_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressInvitationDiff,
+						},
+					},
+				},
+			},
+			"invitation_status": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"accounts": {
 				Type:     schema.TypeSet,
@@ -107,15 +142,15 @@ func resourceUser() *schema.Resource {
 	}
 }
 
-func resourceUsersCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceUsersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	user := mapResourceToUser(d)
 
 	resp, err := client.AddPendingUser(user)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(resp.ID)
@@ -129,12 +164,26 @@ func resourceUsersCreate(d *schema.ResourceData, meta interface{}) error {
 		client.ActivateUser(d.Id())
 	}
 
+	if d.Get("invite.0.send_email").(bool) {
+		invitation := &cfClient.UserInvitation{
+			UserID:  resp.ID,
+			Message: d.Get("invite.0.message").(string),
+			IdpID:   d.Get("invite.0.idp_id").(string),
+		}
+		for _, accountID := range user.Account {
+			err = client.SendUserInvitation(accountID, invitation)
+			if err != nil {
+				return attributeErrorf(cty.GetAttrPath("invite"), "the user %s was created, but the invitation to the account %s failed: %v", resp.ID, accountID, err)
+			}
+		}
+	}
+
 	return nil
 }
 
-func resourceUsersRead(d *schema.ResourceData, meta interface{}) error {
+func resourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	userId := d.Id()
 
@@ -145,21 +194,21 @@ func resourceUsersRead(d *schema.ResourceData, meta interface{}) error {
 
 	user, err := client.GetUserByID(userId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mapUserToResource(*user, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceUsersUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceUsersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// only accounts list
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	accountList := d.Get("accounts").(*schema.Set).List()
 
@@ -167,12 +216,12 @@ func resourceUsersUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	accounts, err := client.GetAccountsList(convertStringArr(accountList))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = client.UpdateUserAccounts(userId, *accounts)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Adding user to users teams
@@ -182,18 +231,18 @@ func resourceUsersUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceUsersDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceUsersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	// To research
 	// it's impossible sometimes to delete user - limit of runtimes or collaborators should be increased.
 
-	client := meta.(*cfClient.Client)
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
 
 	userName := d.Get("user_name").(string)
 
 	err := client.DeleteUser(userName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -205,6 +254,7 @@ func mapUserToResource(user cfClient.User, d *schema.ResourceData) error {
 	d.Set("email", user.Email)
 	d.Set("accounts", flattenUserAccounts(user.Account))
 	d.Set("status", user.Status)
+	d.Set("invitation_status", userInvitationStatus(user))
 	if user.Personal != nil {
 		d.Set("personal", flattenPersonal(user.Personal))
 	}
@@ -218,6 +268,20 @@ func mapUserToResource(user cfClient.User, d *schema.ResourceData) error {
 	return nil
 }
 
+// userInvitationStatus the users are pending until they accept the invitation, the activated users accepted it.
+// The other statuses, e.g. new or suspended, don't tell whether the invitation was accepted and are kept as they are
+func userInvitationStatus(user cfClient.User) string {
+	if user.Status == "activated" {
+		return "accepted"
+	}
+	return user.Status
+}
+
+// suppressInvitationDiff ignores the changes of the invitation once the user is created, it's only sent on creation
+func suppressInvitationDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
 func flattenUserAccounts(accounts []cfClient.Account) []string {
 
 	var accountList []string
@@ -270,6 +334,11 @@ func mapResourceToUser(d *schema.ResourceData) *cfClient.NewUser {
 		}
 	}
 
+	// the invitation signs in the user with the IdP, unless the logins are set explicitly
+	if idpID := d.Get("invite.0.idp_id").(string); idpID != "" && len(d.Get("login").(*schema.Set).List()) == 0 {
+		user.Logins = append(user.Logins, cfClient.Login{IDP: cfClient.IDP{ID: idpID}})
+	}
+
 	if logins, ok := d.GetOk("login"); ok {
 		loginsList := logins.(*schema.Set).List()
 		for _, loginDataI := range loginsList {
@@ -0,0 +1,50 @@
+package codefresh
+
+import (
+	"context"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUserInvitationStatus(t *testing.T) {
+	for status, expected := range map[string]string{
+		"pending":   "pending",
+		"activated": "accepted",
+		"new":       "new",
+		"suspended": "suspended",
+	} {
+		if invitationStatus := userInvitationStatus(cfClient.User{Status: status}); invitationStatus != expected {
+			t.Errorf("status %s: expected the invitation status %s, got %s", status, expected, invitationStatus)
+		}
+	}
+}
+
+func TestUserInvitationDiff(t *testing.T) {
+	state := &terraform.InstanceState{ID: "5f1fd9a54d0fc9b1d39c5ce2", Attributes: map[string]string{
+		"id":                  "5f1fd9a54d0fc9b1d39c5ce2",
+		"user_name":           "jdoe",
+		"email":               "jdoe@example.com",
+		"accounts.#":          "1",
+		"accounts.1559419431": "account",
+		"invite.#":            "1",
+		"invite.0.send_email": "true",
+		"invite.0.message":    "Welcome",
+		"invite.0.idp_id":     "",
+	}}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"user_name": "jdoe",
+		"email":     "jdoe@example.com",
+		"accounts":  []interface{}{"account"},
+		"invite":    []interface{}{map[string]interface{}{"send_email": true, "message": "Welcome to the team"}},
+	})
+
+	diff, err := resourceUser().Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.Attributes["invite.0.message"] != nil {
+		t.Errorf("Expected the changes of the invitation after the creation to be ignored, got %v", diff)
+	}
+}
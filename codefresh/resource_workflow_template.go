@@ -0,0 +1,196 @@
+package codefresh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceWorkflowTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkflowTemplateCreate,
+		ReadContext:   resourceWorkflowTemplateRead,
+		UpdateContext: resourceWorkflowTemplateUpdate,
+		DeleteContext: resourceWorkflowTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"runtime": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"repo_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"revision": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "HEAD",
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"wait_for_ready": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceWorkflowTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	template := *mapResourceToWorkflowTemplate(d)
+
+	resp, err := client.CreateWorkflowTemplate(&template)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.GetID())
+
+	if d.Get("wait_for_ready").(bool) {
+		err = client.WaitForWorkflowTemplateReady(template.Metadata.Runtime, template.Metadata.Name, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return readCreatedEntity(ctx, d, meta, resourceWorkflowTemplateRead, resourceWorkflowTemplateDelete)
+}
+
+func resourceWorkflowTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	if d.Id() == "" {
+		d.SetId("")
+		return nil
+	}
+
+	runtime, name, err := parseWorkflowTemplateID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	template, err := client.GetWorkflowTemplate(runtime, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if template == nil {
+		d.SetId("")
+		return nil
+	}
+
+	err = mapWorkflowTemplateToResource(template, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceWorkflowTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	template := *mapResourceToWorkflowTemplate(d)
+
+	err := client.UpdateWorkflowTemplate(&template)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceWorkflowTemplateRead(ctx, d, meta)
+}
+
+func resourceWorkflowTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(cfClient.CodefreshAPI).WithContext(ctx)
+
+	runtime, name, err := parseWorkflowTemplateID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteWorkflowTemplate(runtime, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// parseWorkflowTemplateID splits the id of the resource, <runtime>/<name>
+func parseWorkflowTemplateID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("[ERROR] Invalid workflow template ID %s, expected <runtime>/<name>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func mapWorkflowTemplateToResource(template *cfClient.WorkflowTemplate, d *schema.ResourceData) error {
+
+	err := d.Set("name", template.Metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("runtime", template.Metadata.Runtime)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("repo_url", template.Source.RepoURL)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("revision", template.Source.Revision)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("path", template.Source.Path)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapResourceToWorkflowTemplate(d *schema.ResourceData) *cfClient.WorkflowTemplate {
+	return &cfClient.WorkflowTemplate{
+		Metadata: cfClient.WorkflowTemplateMetadata{
+			Name:    d.Get("name").(string),
+			Runtime: d.Get("runtime").(string),
+		},
+		Source: cfClient.WorkflowTemplateSource{
+			RepoURL:  d.Get("repo_url").(string),
+			Revision: d.Get("revision").(string),
+			Path:     d.Get("path").(string),
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package codefresh
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// the values of the provider's validate_tags_exist, true is the same as warn
+const (
+	validateTagsExistWarn  = "warn"
+	validateTagsExistError = "error"
+)
+
+// expandValidateTagsExist returns the check of the provider's validate_tags_exist, empty when it's disabled
+func expandValidateTagsExist(value string) string {
+	switch value {
+	case "true", validateTagsExistWarn:
+		return validateTagsExistWarn
+	case validateTagsExistError:
+		return validateTagsExistError
+	}
+	return ""
+}
+
+// tagCatalog the tags used in the account, mapped to the IDs of the pipelines and permissions using them
+type tagCatalog map[string]map[string]bool
+
+type tagCatalogEntry struct {
+	once    sync.Once
+	catalog tagCatalog
+	err     error
+}
+
+// tagCatalogs the catalog is loaded once per provider configuration, as it's checked for each pipeline and permission
+var tagCatalogs sync.Map
+
+func getTagCatalog(client cfClient.CodefreshAPI) (tagCatalog, error) {
+	value, _ := tagCatalogs.LoadOrStore(client, &tagCatalogEntry{})
+	entry := value.(*tagCatalogEntry)
+	entry.once.Do(func() {
+		entry.catalog, entry.err = loadTagCatalog(client)
+	})
+	return entry.catalog, entry.err
+}
+
+func loadTagCatalog(client cfClient.CodefreshAPI) (tagCatalog, error) {
+	catalog := tagCatalog{}
+
+	pipelines, err := client.GetPipelines(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, pipeline := range pipelines {
+		catalog.add(pipeline.Metadata.ID, pipeline.Metadata.Labels.Tags)
+	}
+
+	permissions, err := client.GetPermissionList("", "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		catalog.add(permission.ID, permission.Tags)
+	}
+
+	return catalog, nil
+}
+
+func (catalog tagCatalog) add(id string, tags []string) {
+	for _, tag := range tags {
+		if catalog[tag] == nil {
+			catalog[tag] = map[string]bool{}
+		}
+		catalog[tag][id] = true
+	}
+}
+
+// findUnknownTags returns the tags which aren't used by any pipeline or permission other than the entity with the id
+func (catalog tagCatalog) findUnknownTags(id string, tags []string) []string {
+	var unknownTags []string
+	for _, tag := range tags {
+		if tag == "*" || tag == "untagged" {
+			continue
+		}
+		usedElsewhere := false
+		for otherID := range catalog[tag] {
+			if otherID != id {
+				usedElsewhere = true
+				break
+			}
+		}
+		if !usedElsewhere {
+			unknownTags = append(unknownTags, tag)
+		}
+	}
+	sort.Strings(unknownTags)
+	return unknownTags
+}
+
+// checkUnknownTags checks the tags of the entity which are used nowhere else in the account, likely typos.
+// Only the changed tags are checked, when the provider is configured with validate_tags_exist. The SDK of the
+// provider can't show warnings at plan time, so with warn they're only logged and with error the plan fails
+func checkUnknownTags(d *schema.ResourceDiff, meta interface{}, entity string) error {
+	check := getProviderSettings(meta).validateTagsExist
+	if check == "" || !d.HasChange("tags") {
+		return nil
+	}
+
+	catalog, err := getTagCatalog(meta.(cfClient.CodefreshAPI))
+	if err != nil {
+		return err
+	}
+
+	unknownTags := catalog.findUnknownTags(d.Id(), convertStringArr(d.Get("tags").(*schema.Set).List()))
+	if len(unknownTags) > 0 && check == validateTagsExistError {
+		return fmt.Errorf("the tags %s of %s aren't used by any other pipeline or permission of the account, check them for typos",
+			strings.Join(unknownTags, ", "), entity)
+	}
+	for _, tag := range unknownTags {
+		log.Printf("[WARN] The tag %q of %s isn't used by any other pipeline or permission of the account, check it for typos", tag, entity)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package codefresh
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTagCatalogFindUnknownTags(t *testing.T) {
+	catalog := tagCatalog{}
+	catalog.add("pipeline1", []string{"frontend", "production"})
+	catalog.add("pipeline2", []string{"backend"})
+	catalog.add("permission1", []string{"frontend", "*"})
+
+	cases := []struct {
+		name     string
+		id       string
+		tags     []string
+		expected []string
+	}{
+		{"known tags", "", []string{"frontend", "backend"}, nil},
+		{"typo", "", []string{"fronend", "backend"}, []string{"fronend"}},
+		{"tag only used by the entity itself", "pipeline2", []string{"backend", "frontend"}, []string{"backend"}},
+		{"custom tags are ignored", "", []string{"*", "untagged"}, nil},
+	}
+
+	for _, c := range cases {
+		unknownTags := catalog.findUnknownTags(c.id, c.tags)
+		if !reflect.DeepEqual(unknownTags, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, unknownTags)
+		}
+	}
+}
+
+// tagCatalogAPI mocks the API calls used to load the tag catalog
+type tagCatalogAPI struct {
+	cfClient.CodefreshAPI
+	pipelines   []cfClient.Pipeline
+	permissions []cfClient.Permission
+}
+
+func (api *tagCatalogAPI) GetPipelines(filter *cfClient.PipelinesFilter) ([]cfClient.Pipeline, error) {
+	return api.pipelines, nil
+}
+
+func (api *tagCatalogAPI) GetPermissionList(teamID, action, resource string) ([]cfClient.Permission, error) {
+	return api.permissions, nil
+}
+
+func TestLoadTagCatalog(t *testing.T) {
+	api := &tagCatalogAPI{
+		pipelines: []cfClient.Pipeline{
+			{Metadata: cfClient.Metadata{ID: "pipeline1", Labels: cfClient.Labels{Tags: []string{"frontend"}}}},
+		},
+		permissions: []cfClient.Permission{
+			{ID: "permission1", Tags: []string{"frontend", "backend"}},
+		},
+	}
+
+	catalog, err := loadTagCatalog(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := tagCatalog{
+		"frontend": {"pipeline1": true, "permission1": true},
+		"backend":  {"permission1": true},
+	}
+	if !reflect.DeepEqual(catalog, expected) {
+		t.Errorf("Expected catalog %v, got %v", expected, catalog)
+	}
+}
+
+func TestCheckUnknownTags(t *testing.T) {
+	api := &tagCatalogAPI{
+		pipelines: []cfClient.Pipeline{
+			{Metadata: cfClient.Metadata{ID: "pipeline1", Labels: cfClient.Labels{Tags: []string{"frontend"}}}},
+		},
+	}
+	defer tagCatalogs.Delete(api)
+	defer configuredProviderSettings.Delete(api)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"team": "developers", "resource": "pipeline", "action": "run", "tags": []interface{}{"fronend"},
+	})
+
+	for _, c := range []struct {
+		validateTagsExist string
+		expectError       bool
+	}{
+		{"false", false},
+		{"true", false},
+		{"warn", false},
+		{"error", true},
+	} {
+		configuredProviderSettings.Store(api, &providerSettings{validateTagsExist: expandValidateTagsExist(c.validateTagsExist)})
+		_, err := resourcePermission().Diff(context.Background(), nil, config, api)
+		if (err != nil) != c.expectError {
+			t.Errorf("validate_tags_exist = %s: unexpected error %v", c.validateTagsExist, err)
+		}
+	}
+}
@@ -1,16 +1,104 @@
 package codefresh
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
 
 	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
 	"github.com/dlclark/regexp2"
 	"github.com/ghodss/yaml"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// readCreatedEntity reads the entity just created. When the read fails, e.g. the response can't be mapped to the
+// state, the entity is deleted so that it isn't left in the account outside of the state, and the creation fails.
+// When the delete fails too, the ID is kept so that Terraform marks the resource as tainted and replaces it on the next apply
+func readCreatedEntity(ctx context.Context, d *schema.ResourceData, meta interface{}, read schema.ReadContextFunc, remove schema.DeleteContextFunc) diag.Diagnostics {
+	id := d.Id()
+
+	diags := read(ctx, d, meta)
+	if !diags.HasError() && d.Id() == "" {
+		// keep the ID so that the entity, e.g. not synced yet, is tainted instead of leaked
+		d.SetId(id)
+		return append(diags, diag.Errorf("[ERROR] %s was created but can't be found", id)...)
+	}
+	if !diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[WARN] Failed to read %s after its creation, deleting it. Error = %v", id, diags)
+	d.SetId(id)
+	if deleteDiags := remove(ctx, d, meta); deleteDiags.HasError() {
+		diags = append(diags, diag.Errorf("[ERROR] %s was created but the cleanup failed, it is tainted in the state", id)...)
+		return append(diags, deleteDiags...)
+	}
+	d.SetId("")
+
+	return diags
+}
+
+// attributeErrorf returns an error diagnostic of the attribute, so that Terraform shows where the attribute is configured
+func attributeErrorf(path cty.Path, format string, a ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf(format, a...),
+		AttributePath: path,
+	}}
+}
+
+// reconcileCreatedEntity looks the entity up by name when its creation failed with an unknown outcome, e.g. a gateway
+// timed out while the API created it, so that the entity is stored in the state instead of being created again as a
+// duplicate by the next apply. The entity is only taken over when its name was free before the creation, see
+// isEntityNameFree, otherwise the entity found may have existed before, e.g. a 409 masked by the gateway, and taking
+// it over would bypass adopt_existing. It returns the ID of the entity, or the error of the creation when it wasn't
+// created
+func reconcileCreatedEntity(kind, name string, nameWasFree bool, createErr error, lookup func() (string, error)) (string, error) {
+	if !cfClient.IsUnknownOutcomeError(createErr) {
+		return "", createErr
+	}
+	if !nameWasFree {
+		log.Printf("[DEBUG] The %s %s may have existed before its creation, it isn't taken over", kind, name)
+		return "", createErr
+	}
+	id, err := lookup()
+	if err != nil || id == "" {
+		log.Printf("[DEBUG] The %s %s wasn't created. Error = %v", kind, name, err)
+		return "", createErr
+	}
+	log.Printf("[WARN] The creation of the %s %s failed with %v but it was created", kind, name, createErr)
+	return id, nil
+}
+
+// isEntityNameFree whether no entity has the name before its creation, the lookup returns an empty ID when the name
+// is free. A failed lookup counts as the name taken, so that an unknown outcome of the creation fails as usual
+func isEntityNameFree(lookup func() (string, error)) bool {
+	id, err := lookup()
+	return err == nil && id == ""
+}
+
+// ignoreDeletedEntity returns the error of the deletion of an entity unless the entity is already gone, e.g. it was
+// removed in the UI. Not all the endpoints answer 404 for a missing entity, so on other errors the entity is read
+// back with get to confirm whether it still exists
+func ignoreDeletedEntity(kind string, id string, err error, get func() error) error {
+	if err == nil {
+		return nil
+	}
+	if !cfClient.IsNotFoundError(err) {
+		if getErr := get(); !cfClient.IsNotFoundError(getErr) {
+			return err
+		}
+	}
+	log.Printf("[WARN] The %s %s was already deleted", kind, id)
+	return nil
+}
+
 func convertStringArr(ifaceArr []interface{}) []string {
 	return convertAndMapStringArr(ifaceArr, func(s string) string { return s })
 }
@@ -34,6 +122,41 @@ func convertVariables(vars []cfClient.Variable) map[string]string {
 	return res
 }
 
+// flattenStringMap converts the values of a map returned by the API into strings, the values of other
+// types, e.g. numbers, are converted with a warning instead of failing to set the map
+func flattenStringMap(field string, data map[string]interface{}) map[string]string {
+	res := make(map[string]string, len(data))
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			res[key] = v
+		case nil:
+			res[key] = ""
+		default:
+			text, err := json.Marshal(v)
+			if err != nil {
+				text = []byte(fmt.Sprintf("%v", v))
+			}
+			cfClient.WarnResponseDrift(fmt.Sprintf("%s %s", field, key), string(text), "string")
+			res[key] = string(text)
+		}
+	}
+	return res
+}
+
+// normalizeTags trims the tags and removes the empty and duplicated ones, keeping the original order
+func normalizeTags(tags []string) []string {
+	res := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || cfClient.FindInSlice(res, tag) {
+			continue
+		}
+		res = append(res, tag)
+	}
+	return res
+}
+
 func flattenStringArr(sArr []string) []interface{} {
 	iArr := []interface{}{}
 	for _, s := range sArr {
@@ -116,3 +239,41 @@ func stringIsValidRe2RegExp(i interface{}, k string) (warnings []string, errors
 
 	return warnings, errors
 }
+
+// delimitedRegExp matches the regular expressions in the JavaScript form /pattern/flags stored by the API
+var delimitedRegExp = regexp.MustCompile(`^/(.*)/([a-z]*)$`)
+
+// normalizeBranchRegex returns the canonical form /pattern/flags of the branch regular expression of a trigger,
+// the form stored by the API: a bare pattern is wrapped with the default flags gi, the slashes of the pattern
+// are escaped and the flags are sorted
+func normalizeBranchRegex(value string) string {
+	if value == "" {
+		return value
+	}
+
+	pattern, flags := value, "gi"
+	if match := delimitedRegExp.FindStringSubmatch(value); match != nil {
+		pattern, flags = match[1], match[2]
+	}
+
+	var escapedPattern strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		if r == '/' && !escaped {
+			escapedPattern.WriteRune('\\')
+		}
+		escaped = r == '\\' && !escaped
+		escapedPattern.WriteRune(r)
+	}
+
+	sortedFlags := strings.Split(flags, "")
+	sort.Strings(sortedFlags)
+	uniqueFlags := ""
+	for _, flag := range sortedFlags {
+		if !strings.Contains(uniqueFlags, flag) {
+			uniqueFlags += flag
+		}
+	}
+
+	return "/" + escapedPattern.String() + "/" + uniqueFlags
+}
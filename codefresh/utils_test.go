@@ -0,0 +1,171 @@
+package codefresh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNormalizeBranchRegex(t *testing.T) {
+	cases := map[string]string{
+		"":                 "",
+		"/.*/gi":           "/.*/gi",
+		"master":           "/master/gi",
+		"/master/":         "/master/",
+		"/master/ig":       "/master/gi",
+		"/master/gig":      "/master/gi",
+		"feature/.*":       "/feature\\/.*/gi",
+		"/feature/.*/gi":   "/feature\\/.*/gi",
+		"/feature\\/.*/gi": "/feature\\/.*/gi",
+		"/^(main|dev)$/i":  "/^(main|dev)$/i",
+	}
+
+	for value, expected := range cases {
+		if normalized := normalizeBranchRegex(value); normalized != expected {
+			t.Errorf("Expected %s to be normalized to %s, got %s", value, expected, normalized)
+		}
+		// the normalization is idempotent
+		if normalized := normalizeBranchRegex(expected); normalized != expected {
+			t.Errorf("Expected %s to be kept, got %s", expected, normalized)
+		}
+	}
+}
+
+func TestReadCreatedEntity(t *testing.T) {
+	readOK := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil }
+	readNotFound := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		d.SetId("")
+		return nil
+	}
+	readFailed := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.Errorf("mapping failed")
+	}
+	deleteOK := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil }
+	deleteFailed := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.Errorf("delete failed")
+	}
+
+	cases := []struct {
+		name        string
+		read        schema.ReadContextFunc
+		remove      schema.DeleteContextFunc
+		expectError bool
+		expectedID  string
+	}{
+		{"read succeeds", readOK, deleteFailed, false, "id1"},
+		{"entity not found", readNotFound, deleteOK, true, "id1"},
+		{"read fails and the entity is deleted", readFailed, deleteOK, true, ""},
+		{"read and delete fail", readFailed, deleteFailed, true, "id1"},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId("id1")
+
+		diags := readCreatedEntity(context.Background(), d, nil, c.read, c.remove)
+		if diags.HasError() != c.expectError {
+			t.Errorf("%s: unexpected diagnostics %v", c.name, diags)
+		}
+		if d.Id() != c.expectedID {
+			t.Errorf("%s: expected ID %q, got %q", c.name, c.expectedID, d.Id())
+		}
+	}
+}
+
+func TestIgnoreDeletedEntity(t *testing.T) {
+	notFound := &cfClient.APIError{StatusCode: http.StatusNotFound}
+	badRequest := &cfClient.APIError{StatusCode: http.StatusBadRequest}
+	getFound := func() error { return nil }
+	getNotFound := func() error { return notFound }
+
+	cases := []struct {
+		name        string
+		err         error
+		get         func() error
+		expectError bool
+	}{
+		{"deleted", nil, getFound, false},
+		{"already deleted", notFound, getFound, false},
+		{"delete fails and the entity is gone", badRequest, getNotFound, false},
+		{"delete fails and the entity still exists", badRequest, getFound, true},
+	}
+
+	for _, c := range cases {
+		if err := ignoreDeletedEntity("test", "id1", c.err, c.get); (err != nil) != c.expectError {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
+
+func TestResponseDrift(t *testing.T) {
+	var pipeline cfClient.Pipeline
+	response := `{"spec": {"variables": [
+		{"key": "STRING", "value": "a"},
+		{"key": "NUMBER", "value": 5},
+		{"key": "BOOL", "value": true, "encrypted": true},
+		{"key": "NULL", "value": null}
+	]}}`
+	if err := json.Unmarshal([]byte(response), &pipeline); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expectedVariables := []cfClient.Variable{
+		{Key: "STRING", Value: "a"},
+		{Key: "NUMBER", Value: "5"},
+		{Key: "BOOL", Value: "true", Encrypted: true},
+		{Key: "NULL", Value: ""},
+	}
+	if !reflect.DeepEqual(pipeline.Spec.Variables, expectedVariables) {
+		t.Errorf("expected variables %v, got %v", expectedVariables, pipeline.Spec.Variables)
+	}
+
+	data := flattenStringMap("context data", map[string]interface{}{
+		"string": "a",
+		"number": 1.5,
+		"list":   []interface{}{"a"},
+		"null":   nil,
+	})
+	expectedData := map[string]string{"string": "a", "number": "1.5", "list": `["a"]`, "null": ""}
+	if !reflect.DeepEqual(data, expectedData) {
+		t.Errorf("expected data %v, got %v", expectedData, data)
+	}
+
+	policies := flattenSpecTerminationPolicy([]map[string]interface{}{
+		{"event": 1},
+		{"event": "onTerminate", "type": "unknown"},
+	})
+	if len(policies) != 1 || len(policies[0]) != 0 {
+		t.Errorf("expected the drifted termination policies to be ignored, got %v", policies)
+	}
+}
+
+func TestReconcileCreatedEntity(t *testing.T) {
+	gatewayTimeout := &cfClient.APIError{StatusCode: http.StatusGatewayTimeout, Status: "504 Gateway Timeout"}
+	found := func() (string, error) { return "5f1f0b9c", nil }
+	notFound := func() (string, error) { return "", errors.New("not found") }
+
+	if id, err := reconcileCreatedEntity("pipeline", "project/pipeline", true, gatewayTimeout, found); err != nil || id != "5f1f0b9c" {
+		t.Errorf("Expected the created pipeline to be found, got %s, %v", id, err)
+	}
+	if _, err := reconcileCreatedEntity("pipeline", "project/pipeline", true, gatewayTimeout, notFound); err != gatewayTimeout {
+		t.Errorf("Expected the error of the creation, got %v", err)
+	}
+	if _, err := reconcileCreatedEntity("pipeline", "project/pipeline", false, gatewayTimeout, found); err != gatewayTimeout {
+		t.Errorf("Expected the pipeline existing before the creation not to be taken over, got %v", err)
+	}
+	badRequest := &cfClient.APIError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+	if _, err := reconcileCreatedEntity("pipeline", "project/pipeline", true, badRequest, found); err != badRequest {
+		t.Errorf("Expected the pipeline not to be looked up after a bad request, got %v", err)
+	}
+
+	free := func() (string, error) { return "", nil }
+	if !isEntityNameFree(free) || isEntityNameFree(found) || isEntityNameFree(notFound) {
+		t.Errorf("Expected the name to be free only when the lookup succeeds without an entity")
+	}
+}
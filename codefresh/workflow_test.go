@@ -0,0 +1,210 @@
+package codefresh
+
+import (
+	"fmt"
+	"testing"
+
+	cfClient "github.com/codefresh-io/terraform-provider-codefresh/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The tests in this file cover the interactions between the resources in the realistic flow
+// project -> pipelines -> contexts -> team -> permissions, including the destroy ordering.
+
+var workflowNamePrefix = "TerraformAccTest_"
+
+func TestAccCodefreshWorkflow_ProjectPipelinesPermissions(t *testing.T) {
+	name := workflowNamePrefix + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCodefreshPermissionDestroy,
+			testAccCheckCodefreshTeamDestroy,
+			testAccCheckCodefreshPipelineDestroy,
+			testAccCheckCodefreshContextDestroy,
+			testAccCheckCodefreshProjectDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCodefreshWorkflowConfig(name, "value1", "frontend"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCodefreshProjectExists("codefresh_project.test"),
+					testAccCheckCodefreshContextExists("codefresh_context.test"),
+					testAccCheckCodefreshPipelineExists("codefresh_pipeline.build"),
+					testAccCheckCodefreshPipelineExists("codefresh_pipeline.deploy"),
+					testAccCheckCodefreshPermissionExists("codefresh_permission.run"),
+					resource.TestCheckResourceAttr("codefresh_pipeline.build", "name", name+"/build"),
+					resource.TestCheckResourceAttr("codefresh_pipeline.build", "spec.0.contexts.0", name),
+					resource.TestCheckResourceAttrPair("codefresh_pipeline.build", "project_id", "codefresh_project.test", "id"),
+					resource.TestCheckResourceAttrPair("codefresh_pipeline.deploy", "project_id", "codefresh_project.test", "id"),
+					resource.TestCheckResourceAttrPair("codefresh_permission.run", "team", "codefresh_team.test", "id"),
+				),
+			},
+			{
+				// Updating the context and the tags must not clobber the pipelines or the permissions
+				Config: testAccCodefreshWorkflowConfig(name, "value2", "backend"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("codefresh_context.test", "spec.0.config.0.data.var1", "value2"),
+					resource.TestCheckResourceAttr("codefresh_pipeline.build", "spec.0.contexts.0", name),
+					resource.TestCheckResourceAttr("codefresh_pipeline.build", "spec.0.spec_template.0.revision", "master"),
+					resource.TestCheckResourceAttr("codefresh_pipeline.deploy", "spec.0.spec_template.0.revision", "master"),
+					resource.TestCheckResourceAttr("codefresh_permission.run", "tags.#", "1"),
+					testAccCheckCodefreshPermissionExists("codefresh_permission.run"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCodefreshPermissionExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		apiClient := testAccProvider.Meta().(*cfClient.Client)
+		_, err := apiClient.GetPermissionByID(rs.Primary.ID)
+
+		if err != nil {
+			return fmt.Errorf("error fetching permission with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCodefreshPermissionDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*cfClient.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "codefresh_permission" {
+			continue
+		}
+
+		permissions, err := apiClient.GetPermissionList("", "", "")
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if permission.ID == rs.Primary.ID {
+				return fmt.Errorf("Permission %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCodefreshTeamDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*cfClient.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "codefresh_team" {
+			continue
+		}
+
+		team, err := apiClient.GetTeamByID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if team != nil {
+			return fmt.Errorf("Team %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// CONFIGS
+func testAccCodefreshWorkflowConfig(rName, contextValue, tag string) string {
+	return fmt.Sprintf(`
+resource "codefresh_project" "test" {
+  name = "%[1]s"
+  tags = [%[3]q]
+}
+
+resource "codefresh_context" "test" {
+  name = "%[1]s"
+
+  spec {
+    config {
+      data = {
+        var1 = %[2]q
+      }
+    }
+  }
+}
+
+resource "codefresh_pipeline" "build" {
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  name = "${codefresh_project.test.name}/build"
+  tags = [%[3]q]
+
+  spec {
+    spec_template {
+      repo     = "codefresh-contrib/react-sample-app"
+      path     = "./codefresh.yml"
+      revision = "master"
+      context  = "git"
+    }
+
+    contexts = [
+      codefresh_context.test.name
+    ]
+  }
+}
+
+resource "codefresh_pipeline" "deploy" {
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  name = "${codefresh_project.test.name}/deploy"
+  tags = [%[3]q]
+
+  spec {
+    spec_template {
+      repo     = "codefresh-contrib/react-sample-app"
+      path     = "./codefresh.yml"
+      revision = "master"
+      context  = "git"
+    }
+
+    contexts = [
+      codefresh_context.test.name
+    ]
+  }
+}
+
+resource "codefresh_team" "test" {
+  name = "%[1]s"
+}
+
+resource "codefresh_permission" "run" {
+  team     = codefresh_team.test.id
+  action   = "run"
+  resource = "pipeline"
+  tags     = [%[3]q]
+}
+`, rName, contextValue, tag)
+}
@@ -0,0 +1,26 @@
+package codefresh
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// suppressWriteOnlyDiff suppresses the diffs of a write-only attribute, which is cleared from the state once
+// sent to the API, unless the resource is new or the version attribute changes, e.g. on a rotation.
+// The SDK of the provider doesn't support the write-only arguments of Terraform, the values still appear
+// in the saved plan files but not in the state
+func suppressWriteOnlyDiff(versionKey string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return d.Id() != "" && !d.HasChange(versionKey)
+	}
+}
+
+// suppressListWriteOnlyDiff is suppressWriteOnlyDiff for a write-only attribute of the blocks of a list, e.g. the
+// triggers of a pipeline. The attribute is sent again when the block is new, i.e. its name attribute changes
+func suppressListWriteOnlyDiff(attribute, versionAttribute, nameAttribute string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		prefix := k[:strings.Index(k, attribute)]
+		return d.Id() != "" && !d.HasChange(prefix+versionAttribute) && !d.HasChange(prefix+nameAttribute)
+	}
+}
@@ -0,0 +1,56 @@
+resource "codefresh_project" "project" {
+  name = var.project_name
+  tags = [var.project_name]
+}
+
+resource "codefresh_context" "config" {
+  name = "${var.project_name}-config"
+  spec {
+    config {
+      data = {
+        APP_NAME = var.project_name
+      }
+    }
+  }
+}
+
+resource "codefresh_pipeline" "pipelines" {
+  for_each = var.pipelines
+
+  lifecycle {
+    ignore_changes = [
+      revision
+    ]
+  }
+
+  # The project is part of the pipeline name, this is also what makes the pipelines
+  # depend on the project so that they are destroyed before it
+  name = "${codefresh_project.project.name}/${each.key}"
+  tags = [var.project_name]
+
+  spec {
+    spec_template {
+      repo     = var.repo
+      path     = each.value
+      revision = "master"
+      context  = "github"
+    }
+
+    contexts = [
+      codefresh_context.config.name
+    ]
+  }
+}
+
+resource "codefresh_team" "developers" {
+  name  = "${var.project_name}-developers"
+  users = var.developers
+}
+
+resource "codefresh_permission" "developers" {
+  for_each = toset(["run", "read"])
+  team     = codefresh_team.developers.id
+  action   = each.value
+  resource = "pipeline"
+  tags     = [var.project_name]
+}
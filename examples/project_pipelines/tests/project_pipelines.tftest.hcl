@@ -0,0 +1,54 @@
+# Reference `terraform test` suite for the project_pipelines example.
+# The provider is mocked, so the suite runs without a Codefresh account. The mock still
+# uses the schema of the provider, so the suite fails when the schema used by the module changes.
+# Requires Terraform 1.7 or later, run with `terraform test` from the example directory.
+
+mock_provider "codefresh" {
+  mock_resource "codefresh_team" {
+    defaults = {
+      id = "5f1fd9044d0fc94ddff0d745"
+    }
+  }
+}
+
+variables {
+  api_url      = "https://g.codefresh.io/api"
+  project_name = "myproject"
+  repo         = "codefresh-contrib/react-sample-app"
+  pipelines = {
+    build  = "./codefresh.yml"
+    deploy = "./deploy.yml"
+  }
+  developers = ["5efc3cb6355c6647041b6e49"]
+}
+
+run "pipelines_are_created_in_the_project" {
+  command = plan
+
+  assert {
+    condition     = codefresh_pipeline.pipelines["build"].name == "myproject/build"
+    error_message = "The pipeline name must be prefixed by the project name"
+  }
+
+  assert {
+    condition     = codefresh_pipeline.pipelines["deploy"].spec[0].spec_template[0].path == "./deploy.yml"
+    error_message = "The pipeline must use the YAML of its key"
+  }
+
+  assert {
+    condition     = codefresh_pipeline.pipelines["build"].spec[0].contexts == ["myproject-config"]
+    error_message = "The pipelines must load the project's shared configuration"
+  }
+}
+
+run "developers_can_run_the_project_pipelines" {
+  assert {
+    condition     = codefresh_permission.developers["run"].team == "5f1fd9044d0fc94ddff0d745"
+    error_message = "The permissions must be granted to the developers team"
+  }
+
+  assert {
+    condition     = codefresh_permission.developers["run"].tags == toset(["myproject"])
+    error_message = "The permissions must be limited to the project's tag"
+  }
+}
@@ -0,0 +1,25 @@
+variable api_url {
+  type = string
+}
+
+variable token {
+  type = string
+  default = ""
+}
+
+variable project_name {
+  type = string
+}
+
+variable repo {
+  type = string
+}
+
+variable pipelines {
+  type = map(string)
+}
+
+variable developers {
+  type = list(string)
+  default = []
+}